@@ -0,0 +1,148 @@
+// Command server runs the subscription service HTTP API.
+package main
+
+import (
+	"context"
+	"flag"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	_ "efectz/docs"
+	"github.com/exaring/otelpgx"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/joho/godotenv"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+
+	"efectz/internal/config"
+	"efectz/internal/events"
+	"efectz/internal/migrations"
+	"efectz/internal/notifier"
+	"efectz/internal/observability"
+	"efectz/internal/outbox"
+	"efectz/internal/repository/postgres"
+	"efectz/internal/service"
+	transporthttp "efectz/internal/transport/http"
+)
+
+func main() {
+	migrateOnly := flag.Bool("migrate-only", false, "run pending database migrations and exit, without starting the server")
+	flag.Parse()
+
+	// Load configuration
+	if err := godotenv.Load(); err != nil {
+		slog.Error("Error loading .env file", "error", err)
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load("config.yaml")
+	if err != nil {
+		slog.Error("Error loading config.yaml", "error", err)
+		os.Exit(1)
+	}
+
+	// Initialize logger
+	logger := slog.New(slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	// Run pending migrations before anything touches the schema
+	if err := migrations.Run(cfg.Database.URL); err != nil {
+		logger.Error("failed to run migrations", "error", err)
+		os.Exit(1)
+	}
+	if *migrateOnly {
+		logger.Info("migrations applied, exiting due to --migrate-only")
+		return
+	}
+
+	// Initialize tracing; OTEL_EXPORTER_OTLP_ENDPOINT unset leaves tracing
+	// as an OTel no-op.
+	shutdownTracer, err := observability.InitTracer(context.Background())
+	if err != nil {
+		logger.Error("failed to initialize tracing", "error", err)
+		os.Exit(1)
+	}
+	defer func() {
+		if err := shutdownTracer(context.Background()); err != nil {
+			logger.Error("failed to shut down tracer", "error", err)
+		}
+	}()
+
+	// Initialize database, tracing every query with a child span
+	dbConfig, err := pgxpool.ParseConfig(cfg.Database.URL)
+	if err != nil {
+		logger.Error("failed to parse database URL", "error", err)
+		os.Exit(1)
+	}
+	dbConfig.ConnConfig.Tracer = otelpgx.NewTracer()
+
+	db, err := pgxpool.NewWithConfig(context.Background(), dbConfig)
+	if err != nil {
+		logger.Error("failed to connect to database", "error", err)
+		os.Exit(1)
+	}
+	defer db.Close()
+
+	// Initialize Prometheus metrics
+	metrics := observability.NewMetrics()
+
+	// Initialize the outbox and webhook notification subsystem
+	outboxStore := outbox.NewStore(db)
+	notifierRepo := notifier.NewRepository(db)
+	dispatcher := notifier.NewDispatcher(outboxStore, notifierRepo, logger)
+	lifecycleScanner := notifier.NewLifecycleScanner(db, outboxStore, logger)
+
+	dispatchCtx, cancelDispatch := context.WithCancel(context.Background())
+	defer cancelDispatch()
+	go dispatcher.Run(dispatchCtx)
+	go lifecycleScanner.Run(dispatchCtx, time.Hour)
+	go metrics.RunGaugeUpdater(dispatchCtx, db, time.Minute)
+
+	// Initialize the CloudEvents bus and its dispatcher
+	bus := events.NewBus()
+	eventSubsRepo := events.NewRepository(db)
+	eventDispatcher := events.NewDispatcher(bus, eventSubsRepo, logger)
+	go eventDispatcher.Run(dispatchCtx)
+
+	// Initialize repositories and services
+	subscriptionRepo := postgres.NewSubscriptionRepository(db, outboxStore)
+	subscriptionService := service.NewSubscriptionService(subscriptionRepo, bus, logger)
+
+	// Initialize transport
+	e := echo.New()
+	e.Use(middleware.LoggerWithConfig(middleware.LoggerConfig{
+		Format: "${time_rfc3339} | ${remote_ip} | ${method} | ${uri} | ${status} | ${error}\n",
+	}))
+	e.Use(middleware.Recover())
+	e.Use(observability.TracingMiddleware())
+	e.Use(metrics.Middleware())
+	e.GET("/metrics", metrics.Handler())
+
+	transporthttp.RegisterRoutes(e,
+		transporthttp.NewSubscriptionHandler(subscriptionService, logger),
+		transporthttp.NewNotificationHandler(notifierRepo, logger),
+		transporthttp.NewEventSubscriptionHandler(eventSubsRepo, logger),
+	)
+
+	// Start server
+	go func() {
+		if err := e.Start(":" + cfg.Server.Port); err != nil && err != http.ErrServerClosed {
+			logger.Error("server error", "error", err)
+			os.Exit(1)
+		}
+	}()
+
+	// Graceful shutdown
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
+	<-quit
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := e.Shutdown(ctx); err != nil {
+		logger.Error("server shutdown error", "error", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,34 @@
+// Package config loads service configuration from config.yaml.
+package config
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the top-level application configuration.
+type Config struct {
+	Database struct {
+		URL string `yaml:"url"`
+	} `yaml:"database"`
+	Server struct {
+		Port string `yaml:"port"`
+	} `yaml:"server"`
+}
+
+// Load reads and parses the config file at path.
+func Load(path string) (Config, error) {
+	var cfg Config
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return cfg, fmt.Errorf("read config file: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("unmarshal config: %w", err)
+	}
+	return cfg, nil
+}
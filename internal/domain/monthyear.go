@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// monthYearLayout is the wire and storage format for MonthYear: a calendar
+// month with no day-of-month component.
+const monthYearLayout = "01-2006"
+
+// MonthYear is a calendar month (MM-YYYY), stored as the first day of that
+// month. It replaces the raw MM-YYYY strings subscriptions used to carry,
+// so comparisons are calendar-correct instead of lexicographic.
+type MonthYear struct {
+	time.Time
+}
+
+// ParseMonthYear parses s (format "MM-YYYY") into a MonthYear.
+func ParseMonthYear(s string) (MonthYear, error) {
+	t, err := time.Parse(monthYearLayout, s)
+	if err != nil {
+		return MonthYear{}, fmt.Errorf("invalid MM-YYYY date %q: %w", s, err)
+	}
+	return MonthYear{Time: t}, nil
+}
+
+// String formats my as "MM-YYYY".
+func (my MonthYear) String() string {
+	return my.Time.Format(monthYearLayout)
+}
+
+// MarshalJSON renders my as a "MM-YYYY" JSON string.
+func (my MonthYear) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + my.String() + `"`), nil
+}
+
+// UnmarshalJSON parses a "MM-YYYY" JSON string into my.
+func (my *MonthYear) UnmarshalJSON(data []byte) error {
+	parsed, err := ParseMonthYear(strings.Trim(string(data), `"`))
+	if err != nil {
+		return err
+	}
+	*my = parsed
+	return nil
+}
+
+// Scan implements sql.Scanner, so a MonthYear can be read directly out of a
+// Postgres DATE column.
+func (my *MonthYear) Scan(src interface{}) error {
+	switch v := src.(type) {
+	case nil:
+		return nil
+	case time.Time:
+		my.Time = v
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into MonthYear", src)
+	}
+}
+
+// Value implements driver.Valuer, so a MonthYear can be written to a
+// Postgres DATE column.
+func (my MonthYear) Value() (driver.Value, error) {
+	return my.Time, nil
+}
+
+// AddMonths returns the MonthYear n calendar months after my.
+func (my MonthYear) AddMonths(n int) MonthYear {
+	return MonthYear{Time: my.Time.AddDate(0, n, 0)}
+}
+
+// MonthlyBreakdown is the prorated subscription cost for a single month.
+type MonthlyBreakdown struct {
+	Month MonthYear `json:"month"`
+	Total int       `json:"total"`
+}
+
+// CostSummary is the result of CalculateTotal: the prorated grand total
+// and, when requested, a month-by-month breakdown.
+type CostSummary struct {
+	Total     int                `json:"total"`
+	Breakdown []MonthlyBreakdown `json:"breakdown,omitempty"`
+}
+
+// ProrateCost sums subs' prorated cost over [start, end]: each subscription
+// contributes its full price for every calendar month it overlaps within
+// the window, so a subscription spanning only part of the window is not
+// charged for months it wasn't active.
+func ProrateCost(subs []Subscription, start, end MonthYear) CostSummary {
+	totals := make(map[MonthYear]int)
+	for _, sub := range subs {
+		subStart := sub.StartDate
+		if subStart.Time.Before(start.Time) {
+			subStart = start
+		}
+		subEnd := end
+		if sub.EndDate != nil && sub.EndDate.Time.Before(end.Time) {
+			subEnd = *sub.EndDate
+		}
+		for m := subStart; !m.Time.After(subEnd.Time); m = m.AddMonths(1) {
+			totals[m] += sub.Price
+		}
+	}
+
+	months := make([]MonthYear, 0, len(totals))
+	for m := range totals {
+		months = append(months, m)
+	}
+	sort.Slice(months, func(i, j int) bool { return months[i].Time.Before(months[j].Time) })
+
+	var summary CostSummary
+	for _, m := range months {
+		summary.Total += totals[m]
+		summary.Breakdown = append(summary.Breakdown, MonthlyBreakdown{Month: m, Total: totals[m]})
+	}
+	return summary
+}
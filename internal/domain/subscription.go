@@ -0,0 +1,42 @@
+// Package domain holds the core business types shared across the
+// repository, service, and transport layers.
+package domain
+
+import "time"
+
+// Subscription is a user's subscription to a paid service.
+type Subscription struct {
+	ID          string     `json:"id"`
+	ServiceName string     `json:"service_name"`
+	Price       int        `json:"price"`
+	UserID      string     `json:"user_id"`
+	StartDate   MonthYear  `json:"start_date"`
+	EndDate     *MonthYear `json:"end_date,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+// SubscriptionFilter narrows ListSubscriptions and CalculateTotal queries.
+// ListSubscriptions honors every field; CalculateTotal honors only UserID
+// and ServiceName and ignores the rest.
+type SubscriptionFilter struct {
+	UserID      string
+	ServiceName string
+	PriceMin    *int
+	PriceMax    *int
+	ActiveOn    *MonthYear
+	Expired     *bool
+
+	Limit  int
+	Offset int
+	// Sort is "column:asc" or "column:desc"; see repository.SortableColumns
+	// for the whitelist of accepted columns.
+	Sort string
+}
+
+// SubscriptionPage is a paginated ListSubscriptions result.
+type SubscriptionPage struct {
+	Items      []Subscription `json:"items"`
+	Total      int            `json:"total"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
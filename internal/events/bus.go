@@ -0,0 +1,83 @@
+package events
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// subscriberBuffer is the number of events buffered per subscriber channel
+// before the bus starts dropping the oldest queued event to make room for
+// the newest one.
+const subscriberBuffer = 64
+
+// Bus is a channel-based fan-out between publishers (the CRUD handlers) and
+// consumers (the CloudEvents dispatcher). Each subscriber gets its own
+// buffered channel; a slow subscriber has its oldest buffered event dropped
+// rather than blocking the publisher.
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[string]chan CloudEvent
+	nextID      uint64
+	dropped     atomic.Int64
+}
+
+// NewBus returns an empty event Bus.
+func NewBus() *Bus {
+	return &Bus{subscribers: make(map[string]chan CloudEvent)}
+}
+
+// Subscribe registers a new consumer and returns its subscriber ID and the
+// channel it should receive events on. Call Unsubscribe with the same ID
+// when done to release the channel.
+func (b *Bus) Subscribe() (string, <-chan CloudEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.nextID++
+	id := strconv.FormatUint(b.nextID, 10)
+	ch := make(chan CloudEvent, subscriberBuffer)
+	b.subscribers[id] = ch
+	return id, ch
+}
+
+// Unsubscribe removes a consumer and closes its channel.
+func (b *Bus) Unsubscribe(id string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if ch, ok := b.subscribers[id]; ok {
+		delete(b.subscribers, id)
+		close(ch)
+	}
+}
+
+// Publish fans event out to every current subscriber. A subscriber whose
+// buffer is full has its oldest event dropped and the dropped-event counter
+// incremented, so Publish never blocks on a slow consumer.
+func (b *Bus) Publish(event CloudEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for _, ch := range b.subscribers {
+		select {
+		case ch <- event:
+		default:
+			select {
+			case <-ch:
+				b.dropped.Add(1)
+			default:
+			}
+			select {
+			case ch <- event:
+			default:
+			}
+		}
+	}
+}
+
+// DroppedEvents returns the total number of events dropped across all
+// subscribers due to a full buffer, for exposing as a metric.
+func (b *Bus) DroppedEvents() int64 {
+	return b.dropped.Load()
+}
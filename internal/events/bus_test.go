@@ -0,0 +1,78 @@
+package events
+
+import (
+	"strconv"
+	"testing"
+)
+
+// TestBusPublishFansOutToEverySubscriber covers the basic case: every
+// current subscriber receives its own copy of a published event.
+func TestBusPublishFansOutToEverySubscriber(t *testing.T) {
+	bus := NewBus()
+	_, ch1 := bus.Subscribe()
+	_, ch2 := bus.Subscribe()
+
+	bus.Publish(CloudEvent{ID: "evt-1"})
+
+	got1 := <-ch1
+	got2 := <-ch2
+	if got1.ID != "evt-1" || got2.ID != "evt-1" {
+		t.Fatalf("subscribers received %q, %q, want both %q", got1.ID, got2.ID, "evt-1")
+	}
+}
+
+// TestBusUnsubscribeClosesChannel covers removing a consumer: its channel
+// should be closed so a Dispatcher's Run loop can exit its read loop.
+func TestBusUnsubscribeClosesChannel(t *testing.T) {
+	bus := NewBus()
+	id, ch := bus.Subscribe()
+
+	bus.Unsubscribe(id)
+
+	if _, ok := <-ch; ok {
+		t.Fatal("channel should be closed after Unsubscribe")
+	}
+}
+
+// TestBusPublishDropsOldestWhenSubscriberFallsBehind covers a slow
+// consumer: once its buffer fills, Publish must drop the oldest queued
+// event rather than block, so the newest events are always the ones kept.
+func TestBusPublishDropsOldestWhenSubscriberFallsBehind(t *testing.T) {
+	bus := NewBus()
+	_, ch := bus.Subscribe()
+
+	// Fill the subscriber's buffer, then publish one more: the oldest
+	// event (ID "0") should be dropped to make room for the newest.
+	for i := 0; i < subscriberBuffer+1; i++ {
+		bus.Publish(CloudEvent{ID: strconv.Itoa(i)})
+	}
+
+	if got := bus.DroppedEvents(); got != 1 {
+		t.Fatalf("DroppedEvents() = %d, want 1", got)
+	}
+
+	first := <-ch
+	if first.ID != "1" {
+		t.Fatalf("first buffered event has ID %q, want %q (ID 0 should have been dropped)", first.ID, "1")
+	}
+
+	received := 1
+	for {
+		select {
+		case <-ch:
+			received++
+		default:
+			if received != subscriberBuffer {
+				t.Fatalf("received %d buffered events, want %d", received, subscriberBuffer)
+			}
+			return
+		}
+	}
+}
+
+// TestBusPublishNoSubscribers covers publishing with nobody listening: it
+// must not panic or block.
+func TestBusPublishNoSubscribers(t *testing.T) {
+	bus := NewBus()
+	bus.Publish(CloudEvent{ID: "evt-1"})
+}
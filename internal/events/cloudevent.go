@@ -0,0 +1,64 @@
+// Package events publishes subscription lifecycle changes as CloudEvents
+// and fans them out to registered external consumers over HTTP.
+package events
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// SpecVersion is the CloudEvents spec version this service emits.
+const SpecVersion = "1.0"
+
+// Source identifies this service as the CloudEvents event source.
+const Source = "efectz/subscription-service"
+
+// CloudEvent is a CloudEvents 1.0 JSON envelope.
+type CloudEvent struct {
+	SpecVersion string          `json:"specversion"`
+	Type        string          `json:"type"`
+	Source      string          `json:"source"`
+	ID          string          `json:"id"`
+	Time        time.Time       `json:"time"`
+	Data        json.RawMessage `json:"data"`
+}
+
+// Event types emitted for the subscriptions resource.
+const (
+	TypeSubscriptionCreated = "com.efectz.subscription.created"
+	TypeSubscriptionUpdated = "com.efectz.subscription.updated"
+	TypeSubscriptionDeleted = "com.efectz.subscription.deleted"
+)
+
+// NewCloudEvent builds a CloudEvent envelope around data, which is
+// marshaled to JSON for the "data" field.
+func NewCloudEvent(eventType string, data interface{}) (CloudEvent, error) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return CloudEvent{}, err
+	}
+
+	id, err := newEventID()
+	if err != nil {
+		return CloudEvent{}, err
+	}
+
+	return CloudEvent{
+		SpecVersion: SpecVersion,
+		Type:        eventType,
+		Source:      Source,
+		ID:          id,
+		Time:        time.Now(),
+		Data:        body,
+	}, nil
+}
+
+func newEventID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
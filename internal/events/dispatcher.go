@@ -0,0 +1,101 @@
+package events
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"efectz/internal/webhook"
+)
+
+// SubscriptionLister is the subset of *Repository the dispatcher needs to
+// find the event subscriptions to deliver to. It's declared here, rather
+// than depended on concretely, so tests can exercise the fan-out/matching
+// logic against a fake lister.
+type SubscriptionLister interface {
+	List(ctx context.Context) ([]EventSubscription, error)
+}
+
+// Dispatcher consumes CloudEvents off a Bus subscription and POSTs them to
+// every registered EventSubscription whose resource filter matches.
+type Dispatcher struct {
+	bus    *Bus
+	subs   SubscriptionLister
+	client *http.Client
+	logger *slog.Logger
+}
+
+// NewDispatcher returns a Dispatcher that delivers events from bus to
+// subscriptions registered in subs.
+func NewDispatcher(bus *Bus, subs SubscriptionLister, logger *slog.Logger) *Dispatcher {
+	return &Dispatcher{
+		bus:    bus,
+		subs:   subs,
+		client: webhook.NewSafeClient(10 * time.Second),
+		logger: logger,
+	}
+}
+
+// Run subscribes to the bus and delivers events until ctx is canceled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	id, ch := d.bus.Subscribe()
+	defer d.bus.Unsubscribe(id)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-ch:
+			if !ok {
+				return
+			}
+			d.deliver(ctx, event)
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, event CloudEvent) {
+	subs, err := d.subs.List(ctx)
+	if err != nil {
+		d.logger.Error("failed to list event subscriptions", "error", err)
+		return
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		d.logger.Error("failed to marshal cloud event", "event_id", event.ID, "error", err)
+		return
+	}
+
+	for _, sub := range subs {
+		if !sub.matches(event.Type) {
+			continue
+		}
+		if err := d.post(ctx, sub.CallbackURL, body); err != nil {
+			d.logger.Warn("failed to deliver cloud event", "event_id", event.ID, "callback_url", sub.CallbackURL, "error", err)
+		}
+	}
+}
+
+func (d *Dispatcher) post(ctx context.Context, callbackURL string, body []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, callbackURL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/cloudevents+json")
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("callback %s returned status %d", callbackURL, resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package events
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeSubscriptionLister is an in-memory SubscriptionLister.
+type fakeSubscriptionLister struct {
+	subs []EventSubscription
+}
+
+func (f *fakeSubscriptionLister) List(_ context.Context) ([]EventSubscription, error) {
+	return f.subs, nil
+}
+
+func newTestDispatcher(subs *fakeSubscriptionLister) (*Dispatcher, *Bus) {
+	bus := NewBus()
+	d := NewDispatcher(bus, subs, slog.New(slog.NewTextHandler(io.Discard, nil)))
+	// NewDispatcher wires up the SSRF-safe client, which refuses to dial
+	// loopback addresses - exactly what httptest.NewServer listens on. Use
+	// a plain client so these tests can hit a local test server.
+	d.client = &http.Client{Timeout: time.Second}
+	return d, bus
+}
+
+// TestDispatcherDeliverOnlyPostsToMatchingSubscriptions covers the
+// resource-filter fan-out: a subscription whose filter doesn't match the
+// event's resource must not receive it.
+func TestDispatcherDeliverOnlyPostsToMatchingSubscriptions(t *testing.T) {
+	var matchingHits, otherHits int32
+	matchingServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&matchingHits, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer matchingServer.Close()
+	otherServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&otherHits, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer otherServer.Close()
+
+	subs := &fakeSubscriptionLister{subs: []EventSubscription{
+		{ID: "sub-match", CallbackURL: matchingServer.URL, ResourceFilter: "subscription"},
+		{ID: "sub-other", CallbackURL: otherServer.URL, ResourceFilter: "invoice"},
+	}}
+	d, _ := newTestDispatcher(subs)
+
+	event, err := NewCloudEvent(TypeSubscriptionCreated, map[string]string{"id": "sub-1"})
+	if err != nil {
+		t.Fatalf("NewCloudEvent: %v", err)
+	}
+	d.deliver(context.Background(), event)
+
+	if matchingHits != 1 {
+		t.Errorf("matching subscription received %d requests, want 1", matchingHits)
+	}
+	if otherHits != 0 {
+		t.Errorf("non-matching subscription received %d requests, want 0", otherHits)
+	}
+}
+
+// TestDispatcherRunDeliversPublishedEvents covers the end-to-end path: an
+// event published on the bus while Run is consuming it reaches a matching
+// subscription's callback.
+func TestDispatcherRunDeliversPublishedEvents(t *testing.T) {
+	received := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received <- struct{}{}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	subs := &fakeSubscriptionLister{subs: []EventSubscription{
+		{ID: "sub-1", CallbackURL: server.URL},
+	}}
+	d, bus := newTestDispatcher(subs)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go d.Run(ctx)
+
+	event, err := NewCloudEvent(TypeSubscriptionCreated, map[string]string{"id": "sub-1"})
+	if err != nil {
+		t.Fatalf("NewCloudEvent: %v", err)
+	}
+
+	// Run's Subscribe happens asynchronously once the goroutine is
+	// scheduled, so the first Publish can race it; keep publishing until
+	// the subscription is registered and the event is actually delivered.
+	deadline := time.After(time.Second)
+	for {
+		bus.Publish(event)
+		select {
+		case <-received:
+			return
+		case <-time.After(10 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("timed out waiting for the event to be delivered")
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package events
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// EventSubscription is an external consumer's registration to receive
+// CloudEvents for resources matching ResourceFilter.
+type EventSubscription struct {
+	ID             string    `json:"id"`
+	CallbackURL    string    `json:"callback_url"`
+	ResourceFilter string    `json:"resource_filter,omitempty"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// Repository persists EventSubscriptions in Postgres.
+type Repository struct {
+	db *pgxpool.Pool
+}
+
+// NewRepository returns a Repository backed by db.
+func NewRepository(db *pgxpool.Pool) *Repository {
+	return &Repository{db: db}
+}
+
+// Create registers a new event subscription.
+func (r *Repository) Create(ctx context.Context, sub EventSubscription) (EventSubscription, error) {
+	query := `INSERT INTO event_subscriptions (callback_url, resource_filter, created_at)
+              VALUES ($1, $2, $3) RETURNING id, created_at`
+	sub.CreatedAt = time.Now()
+	err := r.db.QueryRow(ctx, query, sub.CallbackURL, sub.ResourceFilter, sub.CreatedAt).Scan(&sub.ID, &sub.CreatedAt)
+	return sub, err
+}
+
+// List returns every active event subscription.
+func (r *Repository) List(ctx context.Context) ([]EventSubscription, error) {
+	rows, err := r.db.Query(ctx, `SELECT id, callback_url, resource_filter, created_at FROM event_subscriptions`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []EventSubscription
+	for rows.Next() {
+		var sub EventSubscription
+		if err := rows.Scan(&sub.ID, &sub.CallbackURL, &sub.ResourceFilter, &sub.CreatedAt); err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
+
+// Delete removes an event subscription by ID. It returns pgx.ErrNoRows if
+// none existed.
+func (r *Repository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM event_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// matches reports whether sub's resource filter (a resource type such as
+// "subscription") accepts an event whose CloudEvent type is eventType, e.g.
+// "com.efectz.subscription.created".
+func (sub EventSubscription) matches(eventType string) bool {
+	if sub.ResourceFilter == "" {
+		return true
+	}
+	parts := strings.Split(eventType, ".")
+	return len(parts) >= 3 && parts[2] == sub.ResourceFilter
+}
@@ -0,0 +1,29 @@
+package events
+
+import "testing"
+
+// TestEventSubscriptionMatches covers the resource-filter logic: an empty
+// filter accepts everything, a matching filter accepts only that resource,
+// and a CloudEvent type with too few dot-separated segments to contain a
+// resource component never matches a non-empty filter.
+func TestEventSubscriptionMatches(t *testing.T) {
+	tests := []struct {
+		name           string
+		resourceFilter string
+		eventType      string
+		want           bool
+	}{
+		{"empty filter matches anything", "", "com.efectz.subscription.created", true},
+		{"matching resource", "subscription", "com.efectz.subscription.created", true},
+		{"mismatched resource", "subscription", "com.efectz.invoice.created", false},
+		{"too few segments", "subscription", "subscription.created", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sub := EventSubscription{ResourceFilter: tt.resourceFilter}
+			if got := sub.matches(tt.eventType); got != tt.want {
+				t.Errorf("matches(%q) with filter %q = %v, want %v", tt.eventType, tt.resourceFilter, got, tt.want)
+			}
+		})
+	}
+}
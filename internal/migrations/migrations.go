@@ -0,0 +1,55 @@
+// Package migrations embeds the service's SQL schema migrations and runs
+// them against Postgres with golang-migrate.
+package migrations
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"net/url"
+
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/pgx/v5"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+)
+
+//go:embed sql/*.sql
+var sqlFiles embed.FS
+
+// Run applies every pending migration to the database at databaseURL.
+// It is idempotent: running it against an up-to-date database is a no-op.
+func Run(databaseURL string) error {
+	source, err := iofs.New(sqlFiles, "sql")
+	if err != nil {
+		return fmt.Errorf("load embedded migrations: %w", err)
+	}
+
+	dsn, err := pgx5DSN(databaseURL)
+	if err != nil {
+		return fmt.Errorf("build migrator DSN: %w", err)
+	}
+
+	m, err := migrate.NewWithSourceInstance("iofs", source, dsn)
+	if err != nil {
+		return fmt.Errorf("create migrator: %w", err)
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil && !errors.Is(err, migrate.ErrNoChange) {
+		return fmt.Errorf("apply migrations: %w", err)
+	}
+	return nil
+}
+
+// pgx5DSN rewrites databaseURL's scheme to "pgx5", the scheme the
+// golang-migrate pgx/v5 driver dispatches on. Using that driver instead of
+// database/postgres keeps migrations on the same pgx driver as the rest of
+// the service, rather than pulling in lib/pq as a second Postgres client.
+func pgx5DSN(databaseURL string) (string, error) {
+	u, err := url.Parse(databaseURL)
+	if err != nil {
+		return "", err
+	}
+	u.Scheme = "pgx5"
+	return u.String(), nil
+}
@@ -0,0 +1,191 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"efectz/internal/outbox"
+	"efectz/internal/webhook"
+)
+
+// eventPayload is the minimal shape the dispatcher needs out of an outbox
+// event's JSON payload to decide who should receive it.
+type eventPayload struct {
+	UserID      string `json:"user_id"`
+	ServiceName string `json:"service_name"`
+}
+
+// OutboxStore is the subset of *outbox.Store the dispatcher needs to claim
+// events and record delivery outcomes. It's declared here, rather than
+// depended on concretely, so tests can exercise the retry/backoff logic
+// against a fake store.
+type OutboxStore interface {
+	ClaimPending(ctx context.Context, limit int) ([]outbox.Event, error)
+	DeliveredSubscriberIDs(ctx context.Context, eventID string) (map[string]bool, error)
+	MarkSubscriberDelivered(ctx context.Context, eventID, subscriptionID string) error
+	MarkDelivered(ctx context.Context, id string) error
+	MarkFailed(ctx context.Context, e outbox.Event, deliveryErr error, backoff time.Duration) error
+	ReclaimStuck(ctx context.Context, olderThan time.Duration) (int64, error)
+}
+
+// SubscriptionLister is the subset of *Repository the dispatcher needs to
+// find the status subscriptions for an event's user.
+type SubscriptionLister interface {
+	List(ctx context.Context, userID string) ([]StatusSubscription, error)
+}
+
+// Dispatcher polls the outbox for pending events and POSTs them to every
+// matching status subscription's callback URL.
+type Dispatcher struct {
+	outbox OutboxStore
+	subs   SubscriptionLister
+	client *http.Client
+	logger *slog.Logger
+
+	pollInterval      time.Duration
+	batchSize         int
+	baseBackoff       time.Duration
+	maxBackoff        time.Duration
+	deliveringTimeout time.Duration
+}
+
+// NewDispatcher returns a Dispatcher that reads from outboxStore and
+// delivers to subscriptions in subs.
+func NewDispatcher(outboxStore OutboxStore, subs SubscriptionLister, logger *slog.Logger) *Dispatcher {
+	return &Dispatcher{
+		outbox:            outboxStore,
+		subs:              subs,
+		client:            webhook.NewSafeClient(10 * time.Second),
+		logger:            logger,
+		pollInterval:      2 * time.Second,
+		batchSize:         50,
+		baseBackoff:       1 * time.Second,
+		maxBackoff:        2 * time.Minute,
+		deliveringTimeout: 5 * time.Minute,
+	}
+}
+
+// Run polls for pending events until ctx is canceled.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			d.tick(ctx)
+		}
+	}
+}
+
+func (d *Dispatcher) tick(ctx context.Context) {
+	if n, err := d.outbox.ReclaimStuck(ctx, d.deliveringTimeout); err != nil {
+		d.logger.Error("failed to reclaim stuck outbox events", "error", err)
+	} else if n > 0 {
+		d.logger.Warn("reclaimed stuck outbox events", "count", n)
+	}
+
+	events, err := d.outbox.ClaimPending(ctx, d.batchSize)
+	if err != nil {
+		d.logger.Error("failed to claim pending outbox events", "error", err)
+		return
+	}
+
+	for _, event := range events {
+		d.deliver(ctx, event)
+	}
+}
+
+func (d *Dispatcher) deliver(ctx context.Context, event outbox.Event) {
+	var payload eventPayload
+	if err := json.Unmarshal(event.Payload, &payload); err != nil {
+		d.logger.Error("failed to decode outbox payload", "event_id", event.ID, "error", err)
+		d.fail(ctx, event, err)
+		return
+	}
+
+	subs, err := d.subs.List(ctx, payload.UserID)
+	if err != nil {
+		d.logger.Error("failed to list subscriptions for event", "event_id", event.ID, "error", err)
+		d.fail(ctx, event, err)
+		return
+	}
+
+	delivered, err := d.outbox.DeliveredSubscriberIDs(ctx, event.ID)
+	if err != nil {
+		d.logger.Error("failed to load delivered subscribers for event", "event_id", event.ID, "error", err)
+		d.fail(ctx, event, err)
+		return
+	}
+
+	var deliveryErr error
+	for _, sub := range subs {
+		if delivered[sub.ID] || !sub.matches(event.EventType, payload.ServiceName) {
+			continue
+		}
+		if err := d.post(ctx, sub, event); err != nil {
+			deliveryErr = err
+			continue
+		}
+		if err := d.outbox.MarkSubscriberDelivered(ctx, event.ID, sub.ID); err != nil {
+			d.logger.Error("failed to record subscriber delivery", "event_id", event.ID, "subscription_id", sub.ID, "error", err)
+		}
+	}
+
+	if deliveryErr != nil {
+		d.fail(ctx, event, deliveryErr)
+		return
+	}
+
+	if err := d.outbox.MarkDelivered(ctx, event.ID); err != nil {
+		d.logger.Error("failed to mark event delivered", "event_id", event.ID, "error", err)
+	}
+}
+
+func (d *Dispatcher) post(ctx context.Context, sub StatusSubscription, event outbox.Event) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, sub.CallbackURL, bytes.NewReader(event.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-Type", event.EventType)
+	if sub.AuthHeader != nil && *sub.AuthHeader != "" {
+		req.Header.Set("Authorization", *sub.AuthHeader)
+	}
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("callback %s returned status %d", sub.CallbackURL, resp.StatusCode)
+	}
+	return nil
+}
+
+func (d *Dispatcher) fail(ctx context.Context, event outbox.Event, err error) {
+	backoff := d.backoffFor(event.Attempts)
+	if markErr := d.outbox.MarkFailed(ctx, event, err, backoff); markErr != nil {
+		d.logger.Error("failed to record delivery failure", "event_id", event.ID, "error", markErr)
+	}
+	d.logger.Warn("event delivery failed", "event_id", event.ID, "attempt", event.Attempts+1, "error", err)
+}
+
+// backoffFor returns the delay before the next attempt: 1s, 2s, 4s...
+// capped at maxBackoff.
+func (d *Dispatcher) backoffFor(attempts int) time.Duration {
+	backoff := d.baseBackoff << attempts
+	if backoff > d.maxBackoff || backoff <= 0 {
+		return d.maxBackoff
+	}
+	return backoff
+}
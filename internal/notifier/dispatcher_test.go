@@ -0,0 +1,306 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"efectz/internal/outbox"
+)
+
+// fakeOutboxStore is an in-memory OutboxStore used to exercise the
+// dispatcher's claim/retry/dead-letter logic without a database.
+type fakeOutboxStore struct {
+	events        map[string]outbox.Event
+	delivered     map[string]map[string]bool
+	reclaimCalled int
+}
+
+func newFakeOutboxStore(events ...outbox.Event) *fakeOutboxStore {
+	s := &fakeOutboxStore{
+		events:    make(map[string]outbox.Event),
+		delivered: make(map[string]map[string]bool),
+	}
+	for _, e := range events {
+		s.events[e.ID] = e
+	}
+	return s
+}
+
+func (s *fakeOutboxStore) ClaimPending(_ context.Context, limit int) ([]outbox.Event, error) {
+	var claimed []outbox.Event
+	for id, e := range s.events {
+		if e.Status != outbox.StatusPending {
+			continue
+		}
+		e.Status = outbox.StatusDelivering
+		s.events[id] = e
+		claimed = append(claimed, e)
+		if len(claimed) >= limit {
+			break
+		}
+	}
+	return claimed, nil
+}
+
+func (s *fakeOutboxStore) DeliveredSubscriberIDs(_ context.Context, eventID string) (map[string]bool, error) {
+	out := make(map[string]bool)
+	for subID := range s.delivered[eventID] {
+		out[subID] = true
+	}
+	return out, nil
+}
+
+func (s *fakeOutboxStore) MarkSubscriberDelivered(_ context.Context, eventID, subscriptionID string) error {
+	if s.delivered[eventID] == nil {
+		s.delivered[eventID] = make(map[string]bool)
+	}
+	s.delivered[eventID][subscriptionID] = true
+	return nil
+}
+
+func (s *fakeOutboxStore) MarkDelivered(_ context.Context, id string) error {
+	e := s.events[id]
+	e.Status = outbox.StatusDelivered
+	s.events[id] = e
+	return nil
+}
+
+func (s *fakeOutboxStore) MarkFailed(_ context.Context, e outbox.Event, _ error, _ time.Duration) error {
+	e.Attempts++
+	e.Status = outbox.StatusPending
+	if e.Attempts >= e.MaxAttempts {
+		e.Status = outbox.StatusDeadLetter
+	}
+	s.events[e.ID] = e
+	return nil
+}
+
+func (s *fakeOutboxStore) ReclaimStuck(_ context.Context, _ time.Duration) (int64, error) {
+	s.reclaimCalled++
+	var n int64
+	for id, e := range s.events {
+		if e.Status != outbox.StatusDelivering {
+			continue
+		}
+		e.Status = outbox.StatusPending
+		s.events[id] = e
+		n++
+	}
+	return n, nil
+}
+
+// fakeSubscriptionLister is an in-memory SubscriptionLister.
+type fakeSubscriptionLister struct {
+	subs []StatusSubscription
+}
+
+func (f *fakeSubscriptionLister) List(_ context.Context, userID string) ([]StatusSubscription, error) {
+	var out []StatusSubscription
+	for _, sub := range f.subs {
+		if sub.UserID == userID {
+			out = append(out, sub)
+		}
+	}
+	return out, nil
+}
+
+func newTestDispatcher(store *fakeOutboxStore, subs *fakeSubscriptionLister) *Dispatcher {
+	return &Dispatcher{
+		outbox:      store,
+		subs:        subs,
+		client:      &http.Client{Timeout: time.Second},
+		logger:      slog.New(slog.NewTextHandler(io.Discard, nil)),
+		baseBackoff: time.Second,
+		maxBackoff:  time.Minute,
+	}
+}
+
+func newPendingEvent(id, userID string) outbox.Event {
+	payload, _ := json.Marshal(eventPayload{UserID: userID, ServiceName: "netflix"})
+	return outbox.Event{
+		ID:          id,
+		EventType:   "subscription.created",
+		Payload:     payload,
+		Status:      outbox.StatusPending,
+		MaxAttempts: 3,
+	}
+}
+
+// TestDispatcherDeliverRetriesOnlyFailedSubscriber covers the partial
+// failure case: one callback succeeds and one fails, so the event must be
+// retried, but the retry must not re-POST to the subscriber that already
+// succeeded.
+func TestDispatcherDeliverRetriesOnlyFailedSubscriber(t *testing.T) {
+	var okHits, failHits int32
+	okServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&okHits, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer okServer.Close()
+	failServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&failHits, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failServer.Close()
+
+	store := newFakeOutboxStore(newPendingEvent("evt-1", "user-1"))
+	subs := &fakeSubscriptionLister{subs: []StatusSubscription{
+		{ID: "sub-ok", UserID: "user-1", Status: StatusActive, CallbackURL: okServer.URL},
+		{ID: "sub-fail", UserID: "user-1", Status: StatusActive, CallbackURL: failServer.URL},
+	}}
+	d := newTestDispatcher(store, subs)
+	ctx := context.Background()
+
+	event := store.events["evt-1"]
+	d.deliver(ctx, event)
+
+	if got := store.events["evt-1"].Status; got != outbox.StatusPending {
+		t.Fatalf("after partial failure, event status = %q, want %q", got, outbox.StatusPending)
+	}
+	if got := store.events["evt-1"].Attempts; got != 1 {
+		t.Fatalf("after partial failure, attempts = %d, want 1", got)
+	}
+	if !store.delivered["evt-1"]["sub-ok"] {
+		t.Fatal("sub-ok should be recorded as delivered after the first attempt")
+	}
+
+	// Retry: only the previously-failing subscriber should be hit again.
+	event = store.events["evt-1"]
+	event.Status = outbox.StatusPending
+	d.deliver(ctx, event)
+
+	if okHits != 1 {
+		t.Errorf("sub-ok received %d requests, want exactly 1 (must not be re-delivered)", okHits)
+	}
+	if failHits != 2 {
+		t.Errorf("sub-fail received %d requests, want 2 (initial attempt + retry)", failHits)
+	}
+}
+
+// TestDispatcherDeliverDeadLettersAfterMaxAttempts covers the case where a
+// subscriber never succeeds: the event should dead-letter once Attempts
+// reaches MaxAttempts, rather than retry forever.
+func TestDispatcherDeliverDeadLettersAfterMaxAttempts(t *testing.T) {
+	failServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer failServer.Close()
+
+	event := newPendingEvent("evt-1", "user-1")
+	event.MaxAttempts = 2
+	store := newFakeOutboxStore(event)
+	subs := &fakeSubscriptionLister{subs: []StatusSubscription{
+		{ID: "sub-fail", UserID: "user-1", Status: StatusActive, CallbackURL: failServer.URL},
+	}}
+	d := newTestDispatcher(store, subs)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		e := store.events["evt-1"]
+		e.Status = outbox.StatusPending
+		d.deliver(ctx, e)
+	}
+
+	if got := store.events["evt-1"].Status; got != outbox.StatusDeadLetter {
+		t.Fatalf("after MaxAttempts failed deliveries, event status = %q, want %q", got, outbox.StatusDeadLetter)
+	}
+}
+
+// TestDispatcherDeliverMarksDeliveredOnAllSuccess covers the happy path:
+// every matching subscriber succeeds, so the event is marked delivered.
+func TestDispatcherDeliverMarksDeliveredOnAllSuccess(t *testing.T) {
+	okServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer okServer.Close()
+
+	store := newFakeOutboxStore(newPendingEvent("evt-1", "user-1"))
+	subs := &fakeSubscriptionLister{subs: []StatusSubscription{
+		{ID: "sub-ok", UserID: "user-1", Status: StatusActive, CallbackURL: okServer.URL},
+	}}
+	d := newTestDispatcher(store, subs)
+
+	d.deliver(context.Background(), store.events["evt-1"])
+
+	if got := store.events["evt-1"].Status; got != outbox.StatusDelivered {
+		t.Fatalf("event status = %q, want %q", got, outbox.StatusDelivered)
+	}
+}
+
+// TestDispatcherDeliverFailsEventOnUndecodablePayload covers a payload that
+// doesn't match eventPayload's shape (schema drift, a manually-inserted
+// row, etc.): the event must go through the normal fail/retry path rather
+// than being left claimed in StatusDelivering forever, since ClaimPending
+// only ever re-selects status = pending.
+func TestDispatcherDeliverFailsEventOnUndecodablePayload(t *testing.T) {
+	event := outbox.Event{
+		ID:          "evt-1",
+		EventType:   "subscription.created",
+		Payload:     []byte("not json"),
+		Status:      outbox.StatusPending,
+		MaxAttempts: 3,
+	}
+	store := newFakeOutboxStore(event)
+	d := newTestDispatcher(store, &fakeSubscriptionLister{})
+
+	claimed, err := store.ClaimPending(context.Background(), 10)
+	if err != nil || len(claimed) != 1 {
+		t.Fatalf("ClaimPending() = %v, %v", claimed, err)
+	}
+	d.deliver(context.Background(), claimed[0])
+
+	got := store.events["evt-1"]
+	if got.Status != outbox.StatusPending {
+		t.Fatalf("after undecodable payload, event status = %q, want %q (not stuck in %q)",
+			got.Status, outbox.StatusPending, outbox.StatusDelivering)
+	}
+	if got.Attempts != 1 {
+		t.Fatalf("after undecodable payload, attempts = %d, want 1", got.Attempts)
+	}
+}
+
+// TestDispatcherTickReclaimsStuckEvents covers the crash-recovery sweep:
+// tick must reclaim events left in StatusDelivering (e.g. by a dispatcher
+// that died between ClaimPending and a terminal Mark call) before it
+// claims new work.
+func TestDispatcherTickReclaimsStuckEvents(t *testing.T) {
+	event := newPendingEvent("evt-1", "user-1")
+	event.Status = outbox.StatusDelivering
+	store := newFakeOutboxStore(event)
+	d := newTestDispatcher(store, &fakeSubscriptionLister{})
+
+	d.tick(context.Background())
+
+	if store.reclaimCalled != 1 {
+		t.Fatalf("ReclaimStuck called %d times, want 1", store.reclaimCalled)
+	}
+	if got := store.events["evt-1"].Status; got != outbox.StatusDelivered {
+		t.Fatalf("reclaimed event status = %q, want %q (claimed and delivered within the same tick)", got, outbox.StatusDelivered)
+	}
+}
+
+func TestDispatcherBackoffFor(t *testing.T) {
+	d := newTestDispatcher(newFakeOutboxStore(), &fakeSubscriptionLister{})
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, 1 * time.Second},
+		{1, 2 * time.Second},
+		{2, 4 * time.Second},
+		{3, 8 * time.Second},
+		{10, time.Minute}, // capped at maxBackoff
+	}
+	for _, tt := range tests {
+		if got := d.backoffFor(tt.attempts); got != tt.want {
+			t.Errorf("backoffFor(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
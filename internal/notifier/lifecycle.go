@@ -0,0 +1,145 @@
+package notifier
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"efectz/internal/domain"
+	"efectz/internal/outbox"
+)
+
+// Outbox event types for subscription lifecycle notifications that aren't
+// tied to a CRUD operation.
+const (
+	eventSubscriptionExpiring = "subscription.expiring"
+	eventSubscriptionRenewed  = "subscription.renewed"
+)
+
+// lifecycleEvent is the outbox payload for subscription.expiring and
+// subscription.renewed, shaped like subscriptionEvent in
+// repository/postgres so the dispatcher's eventPayload decoding and
+// StatusSubscription.matches filtering work the same for every event type.
+type lifecycleEvent struct {
+	Subscription domain.Subscription `json:"subscription"`
+	UserID       string              `json:"user_id"`
+	ServiceName  string              `json:"service_name"`
+}
+
+// LifecycleScanner periodically scans subscriptions for ones entering
+// their last active month (expiring) or being carried over into a new one
+// (renewed), and enqueues the corresponding outbox event exactly once per
+// month per subscription, tracked via subscriptions.last_notified_month.
+type LifecycleScanner struct {
+	db     *pgxpool.Pool
+	outbox *outbox.Store
+	logger *slog.Logger
+}
+
+// NewLifecycleScanner returns a LifecycleScanner backed by db and outboxStore.
+func NewLifecycleScanner(db *pgxpool.Pool, outboxStore *outbox.Store, logger *slog.Logger) *LifecycleScanner {
+	return &LifecycleScanner{db: db, outbox: outboxStore, logger: logger}
+}
+
+// Run scans for expiring and renewed subscriptions every interval until ctx
+// is canceled.
+func (s *LifecycleScanner) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	s.scan(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.scan(ctx)
+		}
+	}
+}
+
+func (s *LifecycleScanner) scan(ctx context.Context) {
+	currentMonth, err := domain.ParseMonthYear(time.Now().Format("01-2006"))
+	if err != nil {
+		s.logger.Error("failed to compute current month for lifecycle scan", "error", err)
+		return
+	}
+
+	// Expiring: subscriptions whose end_date is the current month, i.e.
+	// this is their last active month, and that haven't already been
+	// notified for this end_date.
+	if err := s.notify(ctx, eventSubscriptionExpiring, currentMonth,
+		`SELECT id, service_name, price, user_id, start_date, end_date, created_at, updated_at
+         FROM subscriptions
+         WHERE end_date = $1 AND (last_notified_month IS NULL OR last_notified_month <> end_date)`); err != nil {
+		s.logger.Error("failed to scan for expiring subscriptions", "error", err)
+	}
+
+	// Renewed: open-ended subscriptions carried over into a new month that
+	// haven't already been notified for this month.
+	if err := s.notify(ctx, eventSubscriptionRenewed, currentMonth,
+		`SELECT id, service_name, price, user_id, start_date, end_date, created_at, updated_at
+         FROM subscriptions
+         WHERE end_date IS NULL AND start_date < $1
+           AND (last_notified_month IS NULL OR last_notified_month <> $1)`); err != nil {
+		s.logger.Error("failed to scan for renewed subscriptions", "error", err)
+	}
+}
+
+// notify runs query (which must select subscriptions' columns in the order
+// domain.Subscription's fields are scanned below, parameterized by
+// currentMonth as $1), and for each matching row enqueues eventType and
+// stamps last_notified_month so the same subscription isn't notified again
+// this month.
+func (s *LifecycleScanner) notify(ctx context.Context, eventType string, currentMonth domain.MonthYear, query string) error {
+	rows, err := s.db.Query(ctx, query, currentMonth)
+	if err != nil {
+		return err
+	}
+	var subs []domain.Subscription
+	for rows.Next() {
+		var sub domain.Subscription
+		if err := rows.Scan(&sub.ID, &sub.ServiceName, &sub.Price, &sub.UserID, &sub.StartDate, &sub.EndDate,
+			&sub.CreatedAt, &sub.UpdatedAt); err != nil {
+			rows.Close()
+			return err
+		}
+		subs = append(subs, sub)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	rows.Close()
+
+	for _, sub := range subs {
+		if err := s.notifyOne(ctx, eventType, sub, currentMonth); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notifyOne enqueues eventType for sub and stamps last_notified_month in
+// the same transaction, mirroring how SubscriptionRepository enqueues
+// lifecycle events alongside the row change that triggers them.
+func (s *LifecycleScanner) notifyOne(ctx context.Context, eventType string, sub domain.Subscription, currentMonth domain.MonthYear) error {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := s.outbox.Enqueue(ctx, tx, "subscription", sub.ID, eventType,
+		lifecycleEvent{Subscription: sub, UserID: sub.UserID, ServiceName: sub.ServiceName}, 0); err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `UPDATE subscriptions SET last_notified_month = $1 WHERE id = $2`,
+		currentMonth, sub.ID); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,138 @@
+// Package notifier manages webhook callback registrations ("status
+// subscriptions") and dispatches outbox events to them over HTTP with
+// exponential backoff.
+package notifier
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// StatusSubscription is a registered webhook callback. EventTypes is a
+// filter: an empty slice means "all event types". ServiceName is an
+// optional filter on top of UserID.
+type StatusSubscription struct {
+	ID          string    `json:"id"`
+	UserID      string    `json:"user_id"`
+	ServiceName *string   `json:"service_name,omitempty"`
+	EventTypes  []string  `json:"event_types,omitempty"`
+	CallbackURL string    `json:"callback_url"`
+	AuthHeader  *string   `json:"auth_header,omitempty"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// Subscription status values.
+const (
+	StatusActive   = "active"
+	StatusDisabled = "disabled"
+)
+
+// Repository persists StatusSubscriptions in Postgres.
+type Repository struct {
+	db *pgxpool.Pool
+}
+
+// NewRepository returns a Repository backed by db.
+func NewRepository(db *pgxpool.Pool) *Repository {
+	return &Repository{db: db}
+}
+
+// Create inserts a new status subscription.
+func (r *Repository) Create(ctx context.Context, sub StatusSubscription) (StatusSubscription, error) {
+	query := `INSERT INTO status_subscriptions
+              (user_id, service_name, event_types, callback_url, auth_header, status, created_at, updated_at)
+              VALUES ($1, $2, $3, $4, $5, $6, $7, $7) RETURNING id, created_at, updated_at`
+	now := time.Now()
+	if sub.Status == "" {
+		sub.Status = StatusActive
+	}
+	err := r.db.QueryRow(ctx, query, sub.UserID, sub.ServiceName, sub.EventTypes, sub.CallbackURL, sub.AuthHeader,
+		sub.Status, now).Scan(&sub.ID, &sub.CreatedAt, &sub.UpdatedAt)
+	return sub, err
+}
+
+// Get fetches a status subscription by ID.
+func (r *Repository) Get(ctx context.Context, id string) (StatusSubscription, error) {
+	var sub StatusSubscription
+	query := `SELECT id, user_id, service_name, event_types, callback_url, auth_header, status, created_at, updated_at
+              FROM status_subscriptions WHERE id = $1`
+	err := r.db.QueryRow(ctx, query, id).Scan(&sub.ID, &sub.UserID, &sub.ServiceName, &sub.EventTypes,
+		&sub.CallbackURL, &sub.AuthHeader, &sub.Status, &sub.CreatedAt, &sub.UpdatedAt)
+	return sub, err
+}
+
+// Update overwrites a status subscription's mutable fields.
+func (r *Repository) Update(ctx context.Context, id string, sub StatusSubscription) (StatusSubscription, error) {
+	query := `UPDATE status_subscriptions SET service_name = $1, event_types = $2, callback_url = $3,
+              auth_header = $4, status = $5, updated_at = $6 WHERE id = $7 RETURNING id, user_id, created_at, updated_at`
+	sub.UpdatedAt = time.Now()
+	err := r.db.QueryRow(ctx, query, sub.ServiceName, sub.EventTypes, sub.CallbackURL, sub.AuthHeader, sub.Status,
+		sub.UpdatedAt, id).Scan(&sub.ID, &sub.UserID, &sub.CreatedAt, &sub.UpdatedAt)
+	return sub, err
+}
+
+// Delete removes a status subscription by ID. It returns pgx.ErrNoRows if
+// none existed.
+func (r *Repository) Delete(ctx context.Context, id string) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM status_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return pgx.ErrNoRows
+	}
+	return nil
+}
+
+// List returns status subscriptions, optionally filtered by user ID.
+func (r *Repository) List(ctx context.Context, userID string) ([]StatusSubscription, error) {
+	query := `SELECT id, user_id, service_name, event_types, callback_url, auth_header, status, created_at, updated_at
+              FROM status_subscriptions WHERE 1=1`
+	args := []interface{}{}
+	if userID != "" {
+		query += " AND user_id = $1"
+		args = append(args, userID)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []StatusSubscription
+	for rows.Next() {
+		var sub StatusSubscription
+		if err := rows.Scan(&sub.ID, &sub.UserID, &sub.ServiceName, &sub.EventTypes, &sub.CallbackURL,
+			&sub.AuthHeader, &sub.Status, &sub.CreatedAt, &sub.UpdatedAt); err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
+
+// matches reports whether sub should receive an event of eventType for the
+// given serviceName.
+func (sub StatusSubscription) matches(eventType, serviceName string) bool {
+	if sub.Status != StatusActive {
+		return false
+	}
+	if sub.ServiceName != nil && *sub.ServiceName != "" && *sub.ServiceName != serviceName {
+		return false
+	}
+	if len(sub.EventTypes) == 0 {
+		return true
+	}
+	for _, t := range sub.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+// Package observability wires Prometheus metrics and OpenTelemetry tracing
+// into the HTTP server and database layer.
+package observability
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"efectz/internal/domain"
+)
+
+// Metrics holds the Prometheus collectors the service exposes at /metrics.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requestsTotal   *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+
+	activeSubscriptions prometheus.Gauge
+	monthlyRevenue      prometheus.Gauge
+}
+
+// NewMetrics registers the service's collectors on a fresh registry.
+func NewMetrics() *Metrics {
+	registry := prometheus.NewRegistry()
+
+	m := &Metrics{
+		registry: registry,
+		requestsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "http_requests_total",
+			Help: "Total HTTP requests by route, method, and status.",
+		}, []string{"route", "method", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "http_request_duration_seconds",
+			Help:    "HTTP request latency by route and method.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"route", "method"}),
+		activeSubscriptions: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "active_subscriptions_total",
+			Help: "Number of subscriptions with no end date or an end date in the current month or later.",
+		}),
+		monthlyRevenue: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "subscription_revenue_month",
+			Help: "Sum of price for subscriptions active in the current calendar month.",
+		}),
+	}
+
+	registry.MustRegister(m.requestsTotal, m.requestDuration, m.activeSubscriptions, m.monthlyRevenue)
+	return m
+}
+
+// Handler returns the /metrics HTTP handler.
+func (m *Metrics) Handler() echo.HandlerFunc {
+	h := promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+	return echo.WrapHandler(h)
+}
+
+// Middleware records request count and latency per route.
+func (m *Metrics) Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			route := c.Path()
+			method := c.Request().Method
+			status := c.Response().Status
+			if err != nil {
+				if he, ok := err.(*echo.HTTPError); ok {
+					status = he.Code
+				} else if status == 0 {
+					status = 500
+				}
+			}
+
+			m.requestsTotal.WithLabelValues(route, method, strconv.Itoa(status)).Inc()
+			m.requestDuration.WithLabelValues(route, method).Observe(time.Since(start).Seconds())
+			return err
+		}
+	}
+}
+
+// RunGaugeUpdater periodically recomputes the business gauges from the
+// database until ctx is canceled.
+func (m *Metrics) RunGaugeUpdater(ctx context.Context, db *pgxpool.Pool, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	m.refreshGauges(ctx, db)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			m.refreshGauges(ctx, db)
+		}
+	}
+}
+
+func (m *Metrics) refreshGauges(ctx context.Context, db *pgxpool.Pool) {
+	currentMonth, err := domain.ParseMonthYear(time.Now().Format("01-2006"))
+	if err != nil {
+		return
+	}
+
+	var active int
+	if err := db.QueryRow(ctx, `SELECT COUNT(*) FROM subscriptions WHERE end_date IS NULL OR end_date >= $1`, currentMonth).
+		Scan(&active); err == nil {
+		m.activeSubscriptions.Set(float64(active))
+	}
+
+	var revenue int
+	if err := db.QueryRow(ctx, `SELECT COALESCE(SUM(price), 0) FROM subscriptions
+              WHERE start_date <= $1 AND (end_date IS NULL OR end_date >= $1)`, currentMonth).
+		Scan(&revenue); err == nil {
+		m.monthlyRevenue.Set(float64(revenue))
+	}
+}
@@ -0,0 +1,37 @@
+package observability
+
+import (
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracingMiddleware starts an HTTP server span for every request, named
+// after the route pattern, and records the response status on it.
+func TracingMiddleware() echo.MiddlewareFunc {
+	tracer := otel.Tracer(ServiceName)
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			ctx, span := tracer.Start(req.Context(), req.Method+" "+c.Path(), trace.WithSpanKind(trace.SpanKindServer))
+			defer span.End()
+
+			c.SetRequest(req.WithContext(ctx))
+
+			err := next(c)
+
+			span.SetAttributes(
+				attribute.String("http.method", req.Method),
+				attribute.String("http.route", c.Path()),
+				attribute.Int("http.status_code", c.Response().Status),
+			)
+			if err != nil {
+				span.SetStatus(codes.Error, err.Error())
+			}
+			return err
+		}
+	}
+}
@@ -0,0 +1,172 @@
+// Package outbox implements the transactional outbox pattern: domain writes
+// and the events they produce are committed atomically in the same SQL
+// transaction, and a separate dispatcher later delivers those events at
+// least once.
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Status values for a row in notification_events.
+const (
+	StatusPending    = "pending"
+	StatusDelivering = "delivering"
+	StatusDelivered  = "delivered"
+	StatusDeadLetter = "dead_letter"
+)
+
+// Event is a single outbox row: a domain event awaiting delivery to zero or
+// more subscribers.
+type Event struct {
+	ID            string
+	AggregateType string
+	AggregateID   string
+	EventType     string
+	Payload       json.RawMessage
+	Status        string
+	Attempts      int
+	MaxAttempts   int
+	NextAttemptAt time.Time
+	LastError     *string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// Store persists outbox events and tracks their delivery state.
+type Store struct {
+	db *pgxpool.Pool
+}
+
+// NewStore returns an outbox Store backed by db.
+func NewStore(db *pgxpool.Pool) *Store {
+	return &Store{db: db}
+}
+
+// Enqueue inserts an event as part of tx, so it becomes visible only if the
+// caller's business transaction commits. maxAttempts <= 0 defaults to 10.
+func (s *Store) Enqueue(ctx context.Context, tx pgx.Tx, aggregateType, aggregateID, eventType string, payload interface{}, maxAttempts int) error {
+	if maxAttempts <= 0 {
+		maxAttempts = 10
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	query := `INSERT INTO notification_events
+              (aggregate_type, aggregate_id, event_type, payload, status, attempts, max_attempts, next_attempt_at, created_at, updated_at)
+              VALUES ($1, $2, $3, $4, $5, 0, $6, $7, $8, $8)`
+	now := time.Now()
+	_, err = tx.Exec(ctx, query, aggregateType, aggregateID, eventType, body, StatusPending, maxAttempts, now, now)
+	return err
+}
+
+// ClaimPending fetches up to limit events that are due for delivery and
+// marks them as delivering so concurrent dispatchers don't double-send.
+func (s *Store) ClaimPending(ctx context.Context, limit int) ([]Event, error) {
+	query := `UPDATE notification_events SET status = $1, updated_at = $2
+              WHERE id IN (
+                  SELECT id FROM notification_events
+                  WHERE status = $3 AND next_attempt_at <= $2
+                  ORDER BY next_attempt_at
+                  LIMIT $4
+                  FOR UPDATE SKIP LOCKED
+              )
+              RETURNING id, aggregate_type, aggregate_id, event_type, payload, status, attempts, max_attempts,
+                        next_attempt_at, last_error, created_at, updated_at`
+	now := time.Now()
+	rows, err := s.db.Query(ctx, query, StatusDelivering, now, StatusPending, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []Event
+	for rows.Next() {
+		var e Event
+		if err := rows.Scan(&e.ID, &e.AggregateType, &e.AggregateID, &e.EventType, &e.Payload, &e.Status,
+			&e.Attempts, &e.MaxAttempts, &e.NextAttemptAt, &e.LastError, &e.CreatedAt, &e.UpdatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// ReclaimStuck resets events stuck in StatusDelivering back to pending so
+// they're picked up again. A dispatcher that crashes (or is killed) between
+// ClaimPending and the terminal MarkDelivered/MarkFailed call leaves its
+// claimed rows in StatusDelivering forever, since ClaimPending only ever
+// re-selects status = pending; this sweep is what gets them unstuck. It
+// returns the number of events reclaimed.
+func (s *Store) ReclaimStuck(ctx context.Context, olderThan time.Duration) (int64, error) {
+	cutoff := time.Now().Add(-olderThan)
+	tag, err := s.db.Exec(ctx, `UPDATE notification_events SET status = $1, updated_at = $2
+              WHERE status = $3 AND updated_at <= $2`,
+		StatusPending, cutoff, StatusDelivering)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
+
+// MarkDelivered flags an event as successfully delivered.
+func (s *Store) MarkDelivered(ctx context.Context, id string) error {
+	_, err := s.db.Exec(ctx, `UPDATE notification_events SET status = $1, updated_at = $2 WHERE id = $3`,
+		StatusDelivered, time.Now(), id)
+	return err
+}
+
+// DeliveredSubscriberIDs returns the IDs of the subscribers that have
+// already received eventID, so a dispatcher retrying a partially-delivered
+// event can skip re-POSTing to callbacks that already succeeded.
+func (s *Store) DeliveredSubscriberIDs(ctx context.Context, eventID string) (map[string]bool, error) {
+	rows, err := s.db.Query(ctx, `SELECT subscription_id FROM event_deliveries WHERE event_id = $1`, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	delivered := make(map[string]bool)
+	for rows.Next() {
+		var subscriptionID string
+		if err := rows.Scan(&subscriptionID); err != nil {
+			return nil, err
+		}
+		delivered[subscriptionID] = true
+	}
+	return delivered, rows.Err()
+}
+
+// MarkSubscriberDelivered records that subscriptionID has successfully
+// received eventID.
+func (s *Store) MarkSubscriberDelivered(ctx context.Context, eventID, subscriptionID string) error {
+	_, err := s.db.Exec(ctx, `INSERT INTO event_deliveries (event_id, subscription_id) VALUES ($1, $2)
+              ON CONFLICT (event_id, subscription_id) DO NOTHING`, eventID, subscriptionID)
+	return err
+}
+
+// MarkFailed records a failed delivery attempt and schedules the next retry
+// using exponential backoff, or dead-letters the event once attempts is
+// exhausted.
+func (s *Store) MarkFailed(ctx context.Context, e Event, deliveryErr error, backoff time.Duration) error {
+	attempts := e.Attempts + 1
+	errMsg := deliveryErr.Error()
+	status := StatusPending
+	nextAttempt := time.Now().Add(backoff)
+	if attempts >= e.MaxAttempts {
+		status = StatusDeadLetter
+	}
+
+	_, err := s.db.Exec(ctx, `UPDATE notification_events
+              SET status = $1, attempts = $2, next_attempt_at = $3, last_error = $4, updated_at = $5
+              WHERE id = $6`,
+		status, attempts, nextAttempt, errMsg, time.Now(), e.ID)
+	return err
+}
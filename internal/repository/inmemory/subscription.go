@@ -0,0 +1,223 @@
+// Package inmemory provides in-memory repository implementations for
+// testing handlers and services without a database.
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"efectz/internal/domain"
+)
+
+// SubscriptionRepository is an in-memory repository.SubscriptionRepository.
+// It does not implement the outbox or webhook-cleanup side effects the
+// Postgres repository has; it only exercises the CRUD contract.
+type SubscriptionRepository struct {
+	mu     sync.Mutex
+	subs   map[string]domain.Subscription
+	nextID int
+}
+
+// NewSubscriptionRepository returns an empty in-memory SubscriptionRepository.
+func NewSubscriptionRepository() *SubscriptionRepository {
+	return &SubscriptionRepository{subs: make(map[string]domain.Subscription)}
+}
+
+func (r *SubscriptionRepository) Create(_ context.Context, sub domain.Subscription) (domain.Subscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.nextID++
+	sub.ID = strconv.Itoa(r.nextID)
+	now := time.Now()
+	sub.CreatedAt = now
+	sub.UpdatedAt = now
+	r.subs[sub.ID] = sub
+	return sub, nil
+}
+
+func (r *SubscriptionRepository) Get(_ context.Context, id string) (domain.Subscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sub, ok := r.subs[id]
+	if !ok {
+		return domain.Subscription{}, pgx.ErrNoRows
+	}
+	return sub, nil
+}
+
+func (r *SubscriptionRepository) Update(_ context.Context, id string, sub domain.Subscription) (domain.Subscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.subs[id]
+	if !ok {
+		return domain.Subscription{}, pgx.ErrNoRows
+	}
+
+	sub.ID = id
+	sub.CreatedAt = existing.CreatedAt
+	sub.UpdatedAt = time.Now()
+	r.subs[id] = sub
+	return sub, nil
+}
+
+func (r *SubscriptionRepository) Delete(_ context.Context, id string) (domain.Subscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sub, ok := r.subs[id]
+	if !ok {
+		return domain.Subscription{}, pgx.ErrNoRows
+	}
+	delete(r.subs, id)
+	return sub, nil
+}
+
+func (r *SubscriptionRepository) List(_ context.Context, filter domain.SubscriptionFilter) ([]domain.Subscription, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	subs := r.filtered(filter)
+
+	sortSubscriptions(subs, filter.Sort)
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	start := filter.Offset
+	if start > len(subs) {
+		start = len(subs)
+	}
+	end := start + limit
+	if end > len(subs) {
+		end = len(subs)
+	}
+	return subs[start:end], nil
+}
+
+// Count returns the number of subscriptions matching filter.
+func (r *SubscriptionRepository) Count(_ context.Context, filter domain.SubscriptionFilter) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return len(r.filtered(filter)), nil
+}
+
+// filtered returns every subscription matching filter's non-paging fields.
+// Callers must hold r.mu.
+func (r *SubscriptionRepository) filtered(filter domain.SubscriptionFilter) []domain.Subscription {
+	var subs []domain.Subscription
+	for _, sub := range r.subs {
+		if filter.UserID != "" && sub.UserID != filter.UserID {
+			continue
+		}
+		if filter.ServiceName != "" && sub.ServiceName != filter.ServiceName {
+			continue
+		}
+		if filter.PriceMin != nil && sub.Price < *filter.PriceMin {
+			continue
+		}
+		if filter.PriceMax != nil && sub.Price > *filter.PriceMax {
+			continue
+		}
+		if filter.ActiveOn != nil {
+			if sub.StartDate.Time.After(filter.ActiveOn.Time) {
+				continue
+			}
+			if sub.EndDate != nil && sub.EndDate.Time.Before(filter.ActiveOn.Time) {
+				continue
+			}
+		}
+		if filter.Expired != nil {
+			now := time.Now()
+			currentMonth := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+			expired := sub.EndDate != nil && sub.EndDate.Time.Before(currentMonth)
+			if expired != *filter.Expired {
+				continue
+			}
+		}
+		subs = append(subs, sub)
+	}
+	return subs
+}
+
+// sortSubscriptions orders subs in place by sortSpec ("column:asc" or
+// "column:desc"), defaulting to created_at descending.
+func sortSubscriptions(subs []domain.Subscription, sortSpec string) {
+	column, ascending := "created_at", false
+	parts := strings.SplitN(sortSpec, ":", 2)
+	if len(parts) == 2 {
+		column = parts[0]
+		ascending = strings.EqualFold(parts[1], "asc")
+	}
+
+	less := func(i, j int) bool {
+		switch column {
+		case "price":
+			return subs[i].Price < subs[j].Price
+		case "start_date":
+			return subs[i].StartDate.Time.Before(subs[j].StartDate.Time)
+		case "end_date":
+			return endDateValue(subs[i]).Before(endDateValue(subs[j]))
+		case "service_name":
+			return subs[i].ServiceName < subs[j].ServiceName
+		case "updated_at":
+			return subs[i].UpdatedAt.Before(subs[j].UpdatedAt)
+		default:
+			return subs[i].CreatedAt.Before(subs[j].CreatedAt)
+		}
+	}
+	if ascending {
+		sort.Slice(subs, less)
+	} else {
+		sort.Slice(subs, func(i, j int) bool { return less(j, i) })
+	}
+}
+
+// endDateMax sorts after every dated EndDate, so the open-ended
+// subscriptions (EndDate == nil) it stands in for end up last in ascending
+// order and first in descending order.
+var endDateMax = time.Date(9999, time.December, 31, 0, 0, 0, 0, time.UTC)
+
+// endDateValue returns sub's end date for sorting, substituting endDateMax
+// for an open-ended subscription.
+func endDateValue(sub domain.Subscription) time.Time {
+	if sub.EndDate == nil {
+		return endDateMax
+	}
+	return sub.EndDate.Time
+}
+
+// Total returns the prorated cost of subscriptions active in [start, end],
+// sharing the same proration logic as the Postgres repository.
+func (r *SubscriptionRepository) Total(_ context.Context, start, end domain.MonthYear, filter domain.SubscriptionFilter) (domain.CostSummary, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var subs []domain.Subscription
+	for _, sub := range r.subs {
+		if filter.UserID != "" && sub.UserID != filter.UserID {
+			continue
+		}
+		if filter.ServiceName != "" && sub.ServiceName != filter.ServiceName {
+			continue
+		}
+		if sub.StartDate.Time.After(end.Time) {
+			continue
+		}
+		if sub.EndDate != nil && sub.EndDate.Time.Before(start.Time) {
+			continue
+		}
+		subs = append(subs, sub)
+	}
+	return domain.ProrateCost(subs, start, end), nil
+}
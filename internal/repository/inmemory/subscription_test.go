@@ -0,0 +1,119 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"efectz/internal/domain"
+	"efectz/internal/repository"
+)
+
+func mustMonthYear(t *testing.T, s string) domain.MonthYear {
+	t.Helper()
+	my, err := domain.ParseMonthYear(s)
+	if err != nil {
+		t.Fatalf("ParseMonthYear(%q): %v", s, err)
+	}
+	return my
+}
+
+func TestSubscriptionRepositoryCreateGetDelete(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSubscriptionRepository()
+
+	created, err := repo.Create(ctx, domain.Subscription{
+		ServiceName: "netflix",
+		Price:       1500,
+		UserID:      "user-1",
+		StartDate:   mustMonthYear(t, "01-2024"),
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.ID == "" {
+		t.Fatal("Create did not assign an ID")
+	}
+
+	got, err := repo.Get(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.ServiceName != "netflix" {
+		t.Errorf("Get returned ServiceName %q, want %q", got.ServiceName, "netflix")
+	}
+
+	if _, err := repo.Delete(ctx, created.ID); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := repo.Get(ctx, created.ID); err == nil {
+		t.Error("Get after Delete: expected an error, got nil")
+	}
+}
+
+// TestSubscriptionRepositoryListSortsEveryWhitelistedColumn guards against
+// sortSubscriptions silently falling back to created_at for a column that
+// repository.SortableColumns claims is supported.
+func TestSubscriptionRepositoryListSortsEveryWhitelistedColumn(t *testing.T) {
+	ctx := context.Background()
+
+	for column := range repository.SortableColumns {
+		t.Run(column, func(t *testing.T) {
+			repo := NewSubscriptionRepository()
+			for i := 0; i < 3; i++ {
+				endDate := mustMonthYear(t, "06-2024").AddMonths(i)
+				if _, err := repo.Create(ctx, domain.Subscription{
+					ServiceName: string(rune('a' + i)),
+					Price:       (i + 1) * 100,
+					UserID:      "user-1",
+					StartDate:   mustMonthYear(t, "01-2024").AddMonths(i),
+					EndDate:     &endDate,
+				}); err != nil {
+					t.Fatalf("Create: %v", err)
+				}
+				time.Sleep(time.Millisecond)
+			}
+
+			asc, err := repo.List(ctx, domain.SubscriptionFilter{Sort: column + ":asc", Limit: 10})
+			if err != nil {
+				t.Fatalf("List asc: %v", err)
+			}
+			desc, err := repo.List(ctx, domain.SubscriptionFilter{Sort: column + ":desc", Limit: 10})
+			if err != nil {
+				t.Fatalf("List desc: %v", err)
+			}
+			if len(asc) != 3 || len(desc) != 3 {
+				t.Fatalf("List returned %d/%d items, want 3/3", len(asc), len(desc))
+			}
+			for i := range asc {
+				if asc[i].ID != desc[len(desc)-1-i].ID {
+					t.Errorf("sort %q: asc[%d]=%s, want desc reversed %s", column, i, asc[i].ID, desc[len(desc)-1-i].ID)
+				}
+			}
+		})
+	}
+}
+
+func TestSubscriptionRepositoryListFiltersByPriceRange(t *testing.T) {
+	ctx := context.Background()
+	repo := NewSubscriptionRepository()
+	for _, price := range []int{100, 500, 1000} {
+		if _, err := repo.Create(ctx, domain.Subscription{
+			ServiceName: "svc",
+			Price:       price,
+			UserID:      "user-1",
+			StartDate:   mustMonthYear(t, "01-2024"),
+		}); err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	min, max := 200, 900
+	items, err := repo.List(ctx, domain.SubscriptionFilter{PriceMin: &min, PriceMax: &max, Limit: 10})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(items) != 1 || items[0].Price != 500 {
+		t.Fatalf("List with price range [%d, %d] = %+v, want a single 500 item", min, max, items)
+	}
+}
@@ -0,0 +1,333 @@
+// Package postgres implements the repository interfaces against a Postgres
+// database reached through pgxpool.
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"efectz/internal/domain"
+	"efectz/internal/outbox"
+	"efectz/internal/repository"
+)
+
+// Outbox event types for the subscriptions aggregate.
+const (
+	eventSubscriptionCreated = "subscription.created"
+	eventSubscriptionUpdated = "subscription.updated"
+	eventSubscriptionDeleted = "subscription.deleted"
+)
+
+// subscriptionEvent is the outbox payload for subscription lifecycle events.
+type subscriptionEvent struct {
+	Subscription domain.Subscription `json:"subscription"`
+	UserID       string              `json:"user_id"`
+	ServiceName  string              `json:"service_name"`
+}
+
+// SubscriptionRepository is the Postgres-backed repository.SubscriptionRepository.
+// It owns the transaction boundary for the outbox write and, on delete,
+// for the webhook-registration cascade cleanup, since both are SQL
+// statements that must commit atomically with the subscription change.
+type SubscriptionRepository struct {
+	db     *pgxpool.Pool
+	outbox *outbox.Store
+}
+
+// NewSubscriptionRepository returns a Postgres-backed SubscriptionRepository.
+func NewSubscriptionRepository(db *pgxpool.Pool, outboxStore *outbox.Store) *SubscriptionRepository {
+	return &SubscriptionRepository{db: db, outbox: outboxStore}
+}
+
+// lockUser takes a transaction-scoped advisory lock on userID, released
+// automatically on commit or rollback. Create and Delete both take it
+// before reading or writing a user's subscriptions so the two can't
+// interleave under READ COMMITTED: without it, a Delete's "this user has
+// no subscriptions left" check and a concurrent Create's INSERT aren't
+// serialized against each other, and the Delete can wipe a user's
+// status_subscriptions for a subscription that re-appears moments later.
+func lockUser(ctx context.Context, tx pgx.Tx, userID string) error {
+	if _, err := tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1))`, userID); err != nil {
+		return fmt.Errorf("lock user %q: %w", userID, err)
+	}
+	return nil
+}
+
+// Create inserts sub and enqueues its outbox event in the same transaction.
+// It takes lockUser's per-user advisory lock first so it can't race
+// Delete's "does this user have any subscriptions left" check.
+func (r *SubscriptionRepository) Create(ctx context.Context, sub domain.Subscription) (domain.Subscription, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return domain.Subscription{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockUser(ctx, tx, sub.UserID); err != nil {
+		return domain.Subscription{}, err
+	}
+
+	query := `INSERT INTO subscriptions (service_name, price, user_id, start_date, end_date, created_at, updated_at)
+              VALUES ($1, $2, $3, $4, $5, now(), now()) RETURNING id, created_at, updated_at`
+	if err := tx.QueryRow(ctx, query, sub.ServiceName, sub.Price, sub.UserID, sub.StartDate, sub.EndDate).
+		Scan(&sub.ID, &sub.CreatedAt, &sub.UpdatedAt); err != nil {
+		return domain.Subscription{}, fmt.Errorf("insert subscription: %w", err)
+	}
+
+	if err := r.outbox.Enqueue(ctx, tx, "subscription", sub.ID, eventSubscriptionCreated,
+		subscriptionEvent{Subscription: sub, UserID: sub.UserID, ServiceName: sub.ServiceName}, 0); err != nil {
+		return domain.Subscription{}, fmt.Errorf("enqueue subscription created event: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Subscription{}, fmt.Errorf("commit transaction: %w", err)
+	}
+	return sub, nil
+}
+
+// Get fetches a subscription by ID.
+func (r *SubscriptionRepository) Get(ctx context.Context, id string) (domain.Subscription, error) {
+	var sub domain.Subscription
+	query := `SELECT id, service_name, price, user_id, start_date, end_date, created_at, updated_at
+              FROM subscriptions WHERE id = $1`
+	err := r.db.QueryRow(ctx, query, id).Scan(&sub.ID, &sub.ServiceName, &sub.Price, &sub.UserID,
+		&sub.StartDate, &sub.EndDate, &sub.CreatedAt, &sub.UpdatedAt)
+	return sub, err
+}
+
+// Update overwrites a subscription's mutable fields and enqueues its
+// outbox event in the same transaction.
+func (r *SubscriptionRepository) Update(ctx context.Context, id string, sub domain.Subscription) (domain.Subscription, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return domain.Subscription{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Update can reassign user_id, so it can race Delete's "does this user
+	// have any subscriptions left" check on either the losing or gaining
+	// user. Take lockUser on both (sorted, so two concurrent Updates can't
+	// deadlock taking the same pair of locks in opposite order) before
+	// touching the row.
+	var oldUserID string
+	if err := tx.QueryRow(ctx, `SELECT user_id FROM subscriptions WHERE id = $1`, id).Scan(&oldUserID); err != nil {
+		return domain.Subscription{}, err
+	}
+	lockIDs := []string{oldUserID}
+	if sub.UserID != oldUserID {
+		lockIDs = append(lockIDs, sub.UserID)
+	}
+	sort.Strings(lockIDs)
+	for _, uid := range lockIDs {
+		if err := lockUser(ctx, tx, uid); err != nil {
+			return domain.Subscription{}, err
+		}
+	}
+
+	query := `UPDATE subscriptions SET service_name = $1, price = $2, user_id = $3, start_date = $4, end_date = $5,
+              updated_at = now() WHERE id = $6 RETURNING id, created_at, updated_at`
+	if err := tx.QueryRow(ctx, query, sub.ServiceName, sub.Price, sub.UserID, sub.StartDate, sub.EndDate, id).
+		Scan(&sub.ID, &sub.CreatedAt, &sub.UpdatedAt); err != nil {
+		return domain.Subscription{}, err
+	}
+
+	if err := r.outbox.Enqueue(ctx, tx, "subscription", sub.ID, eventSubscriptionUpdated,
+		subscriptionEvent{Subscription: sub, UserID: sub.UserID, ServiceName: sub.ServiceName}, 0); err != nil {
+		return domain.Subscription{}, fmt.Errorf("enqueue subscription updated event: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Subscription{}, fmt.Errorf("commit transaction: %w", err)
+	}
+	return sub, nil
+}
+
+// Delete removes a subscription, enqueues its outbox event, and, once the
+// owning user has no subscriptions left, tears down their webhook
+// registrations too (mirroring the k8splugin status-notification design).
+func (r *SubscriptionRepository) Delete(ctx context.Context, id string) (domain.Subscription, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return domain.Subscription{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Take the same per-user lock Create takes, before touching any rows,
+	// so a concurrent Create for this user can't commit its INSERT between
+	// our NOT EXISTS check below and our DELETE: the two transactions are
+	// now fully serialized on user_id rather than just racing on timing.
+	var userID string
+	if err := tx.QueryRow(ctx, `SELECT user_id FROM subscriptions WHERE id = $1`, id).Scan(&userID); err != nil {
+		return domain.Subscription{}, err
+	}
+	if err := lockUser(ctx, tx, userID); err != nil {
+		return domain.Subscription{}, err
+	}
+
+	var sub domain.Subscription
+	query := `DELETE FROM subscriptions WHERE id = $1
+              RETURNING id, service_name, price, user_id, start_date, end_date, created_at, updated_at`
+	if err := tx.QueryRow(ctx, query, id).Scan(&sub.ID, &sub.ServiceName, &sub.Price, &sub.UserID,
+		&sub.StartDate, &sub.EndDate, &sub.CreatedAt, &sub.UpdatedAt); err != nil {
+		return domain.Subscription{}, err
+	}
+
+	if err := r.outbox.Enqueue(ctx, tx, "subscription", sub.ID, eventSubscriptionDeleted,
+		subscriptionEvent{Subscription: sub, UserID: sub.UserID, ServiceName: sub.ServiceName}, 0); err != nil {
+		return domain.Subscription{}, fmt.Errorf("enqueue subscription deleted event: %w", err)
+	}
+
+	// Delete the user's webhook registrations in the same statement that
+	// re-verifies they have no subscriptions left, so a concurrent Create
+	// for the same user can't have its subscription's webhooks cleaned up
+	// out from under it after this transaction commits.
+	if _, err := tx.Exec(ctx, `DELETE FROM status_subscriptions
+              WHERE user_id = $1 AND NOT EXISTS (SELECT 1 FROM subscriptions WHERE user_id = $1)`, sub.UserID); err != nil {
+		return domain.Subscription{}, fmt.Errorf("clean up status subscriptions: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.Subscription{}, fmt.Errorf("commit transaction: %w", err)
+	}
+	return sub, nil
+}
+
+// List returns the page of subscriptions matching filter.
+func (r *SubscriptionRepository) List(ctx context.Context, filter domain.SubscriptionFilter) ([]domain.Subscription, error) {
+	where, args := filterConditions(filter)
+	query := `SELECT id, service_name, price, user_id, start_date, end_date, created_at, updated_at
+              FROM subscriptions WHERE 1=1` + where
+
+	sortColumn, sortDir := sortClause(filter.Sort)
+	query += fmt.Sprintf(" ORDER BY %s %s", sortColumn, sortDir)
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	args = append(args, limit, filter.Offset)
+	query += fmt.Sprintf(" LIMIT $%d OFFSET $%d", len(args)-1, len(args))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var subs []domain.Subscription
+	for rows.Next() {
+		var sub domain.Subscription
+		if err := rows.Scan(&sub.ID, &sub.ServiceName, &sub.Price, &sub.UserID, &sub.StartDate, &sub.EndDate,
+			&sub.CreatedAt, &sub.UpdatedAt); err != nil {
+			return nil, err
+		}
+		subs = append(subs, sub)
+	}
+	return subs, rows.Err()
+}
+
+// Count returns the total number of subscriptions matching filter.
+func (r *SubscriptionRepository) Count(ctx context.Context, filter domain.SubscriptionFilter) (int, error) {
+	where, args := filterConditions(filter)
+	query := `SELECT COUNT(*) FROM subscriptions WHERE 1=1` + where
+
+	var count int
+	err := r.db.QueryRow(ctx, query, args...).Scan(&count)
+	return count, err
+}
+
+// filterConditions builds the "AND ..." SQL fragment and matching args for
+// the non-paging fields of filter.
+func filterConditions(filter domain.SubscriptionFilter) (string, []interface{}) {
+	var b strings.Builder
+	var args []interface{}
+
+	if filter.UserID != "" {
+		args = append(args, filter.UserID)
+		fmt.Fprintf(&b, " AND user_id = $%d", len(args))
+	}
+	if filter.ServiceName != "" {
+		args = append(args, filter.ServiceName)
+		fmt.Fprintf(&b, " AND service_name = $%d", len(args))
+	}
+	if filter.PriceMin != nil {
+		args = append(args, *filter.PriceMin)
+		fmt.Fprintf(&b, " AND price >= $%d", len(args))
+	}
+	if filter.PriceMax != nil {
+		args = append(args, *filter.PriceMax)
+		fmt.Fprintf(&b, " AND price <= $%d", len(args))
+	}
+	if filter.ActiveOn != nil {
+		args = append(args, *filter.ActiveOn, *filter.ActiveOn)
+		fmt.Fprintf(&b, " AND start_date <= $%d AND (end_date IS NULL OR end_date >= $%d)", len(args)-1, len(args))
+	}
+	if filter.Expired != nil {
+		if *filter.Expired {
+			b.WriteString(" AND end_date < date_trunc('month', CURRENT_DATE)")
+		} else {
+			b.WriteString(" AND (end_date IS NULL OR end_date >= date_trunc('month', CURRENT_DATE))")
+		}
+	}
+	return b.String(), args
+}
+
+// sortClause validates sort (format "column:asc" or "column:desc") against
+// repository.SortableColumns and returns a safe column and direction,
+// defaulting to "created_at DESC" for an empty or unrecognized sort key.
+func sortClause(sort string) (string, string) {
+	column, direction := "created_at", "DESC"
+	parts := strings.SplitN(sort, ":", 2)
+	if len(parts) == 2 && repository.SortableColumns[parts[0]] {
+		column = parts[0]
+		if strings.EqualFold(parts[1], "asc") {
+			direction = "ASC"
+		} else {
+			direction = "DESC"
+		}
+	}
+	return column, direction
+}
+
+// Total returns the prorated cost of subscriptions active in [start, end].
+// It fetches the overlapping rows and leaves the month-by-month proration
+// to domain.ProrateCost, which the in-memory repository shares.
+func (r *SubscriptionRepository) Total(ctx context.Context, start, end domain.MonthYear, filter domain.SubscriptionFilter) (domain.CostSummary, error) {
+	query := `SELECT id, service_name, price, user_id, start_date, end_date, created_at, updated_at
+              FROM subscriptions WHERE start_date <= $1 AND (end_date IS NULL OR end_date >= $2)`
+	args := []interface{}{end, start}
+	if filter.UserID != "" {
+		query += fmt.Sprintf(" AND user_id = $%d", len(args)+1)
+		args = append(args, filter.UserID)
+	}
+	if filter.ServiceName != "" {
+		query += fmt.Sprintf(" AND service_name = $%d", len(args)+1)
+		args = append(args, filter.ServiceName)
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return domain.CostSummary{}, err
+	}
+	defer rows.Close()
+
+	var subs []domain.Subscription
+	for rows.Next() {
+		var sub domain.Subscription
+		if err := rows.Scan(&sub.ID, &sub.ServiceName, &sub.Price, &sub.UserID, &sub.StartDate, &sub.EndDate,
+			&sub.CreatedAt, &sub.UpdatedAt); err != nil {
+			return domain.CostSummary{}, err
+		}
+		subs = append(subs, sub)
+	}
+	if err := rows.Err(); err != nil {
+		return domain.CostSummary{}, err
+	}
+
+	return domain.ProrateCost(subs, start, end), nil
+}
@@ -0,0 +1,42 @@
+// Package repository defines the storage-agnostic interfaces the service
+// layer depends on, so business logic never imports a driver package
+// directly. See postgres and inmemory for implementations.
+package repository
+
+import (
+	"context"
+
+	"efectz/internal/domain"
+)
+
+// SubscriptionRepository persists and queries subscriptions.
+type SubscriptionRepository interface {
+	Create(ctx context.Context, sub domain.Subscription) (domain.Subscription, error)
+	Get(ctx context.Context, id string) (domain.Subscription, error)
+	Update(ctx context.Context, id string, sub domain.Subscription) (domain.Subscription, error)
+	// Delete removes the subscription and returns the row as it was just
+	// before deletion, so the caller can build lifecycle events from it.
+	Delete(ctx context.Context, id string) (domain.Subscription, error)
+	// List returns the page of subscriptions matching filter, honoring its
+	// Limit, Offset, and Sort fields.
+	List(ctx context.Context, filter domain.SubscriptionFilter) ([]domain.Subscription, error)
+	// Count returns the total number of subscriptions matching filter,
+	// ignoring its Limit, Offset, and Sort fields.
+	Count(ctx context.Context, filter domain.SubscriptionFilter) (int, error)
+	// Total returns the prorated cost of subscriptions active between start
+	// and end (inclusive), optionally narrowed by filter. See
+	// domain.ProrateCost for how the proration is computed.
+	Total(ctx context.Context, start, end domain.MonthYear, filter domain.SubscriptionFilter) (domain.CostSummary, error)
+}
+
+// SortableColumns whitelists the subscription columns ListSubscriptions may
+// sort by, so a "sort" query parameter can never be interpolated into SQL
+// outside this set.
+var SortableColumns = map[string]bool{
+	"created_at":   true,
+	"updated_at":   true,
+	"price":        true,
+	"start_date":   true,
+	"end_date":     true,
+	"service_name": true,
+}
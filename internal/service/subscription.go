@@ -0,0 +1,126 @@
+// Package service holds the business logic layer. Services depend on the
+// repository interfaces, never on a specific driver, so they can be
+// exercised with the in-memory repositories in tests.
+package service
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+
+	"efectz/internal/domain"
+	"efectz/internal/events"
+	"efectz/internal/repository"
+)
+
+// SubscriptionService implements the subscription use cases on top of a
+// SubscriptionRepository, publishing a CloudEvent for every lifecycle
+// change.
+type SubscriptionService struct {
+	repo   repository.SubscriptionRepository
+	bus    *events.Bus
+	logger *slog.Logger
+}
+
+// NewSubscriptionService returns a SubscriptionService backed by repo.
+func NewSubscriptionService(repo repository.SubscriptionRepository, bus *events.Bus, logger *slog.Logger) *SubscriptionService {
+	return &SubscriptionService{repo: repo, bus: bus, logger: logger}
+}
+
+// subscriptionEventData is the CloudEvent "data" payload for subscription
+// lifecycle events.
+type subscriptionEventData struct {
+	Subscription domain.Subscription `json:"subscription"`
+	UserID       string              `json:"user_id"`
+	ServiceName  string              `json:"service_name"`
+}
+
+// CreateSubscription creates sub and publishes a created event.
+func (s *SubscriptionService) CreateSubscription(ctx context.Context, sub domain.Subscription) (domain.Subscription, error) {
+	created, err := s.repo.Create(ctx, sub)
+	if err != nil {
+		return domain.Subscription{}, err
+	}
+	s.publish(events.TypeSubscriptionCreated, created)
+	return created, nil
+}
+
+// GetSubscription fetches a subscription by ID.
+func (s *SubscriptionService) GetSubscription(ctx context.Context, id string) (domain.Subscription, error) {
+	return s.repo.Get(ctx, id)
+}
+
+// UpdateSubscription updates a subscription and publishes an updated event.
+func (s *SubscriptionService) UpdateSubscription(ctx context.Context, id string, sub domain.Subscription) (domain.Subscription, error) {
+	updated, err := s.repo.Update(ctx, id, sub)
+	if err != nil {
+		return domain.Subscription{}, err
+	}
+	s.publish(events.TypeSubscriptionUpdated, updated)
+	return updated, nil
+}
+
+// DeleteSubscription deletes a subscription and publishes a deleted event.
+func (s *SubscriptionService) DeleteSubscription(ctx context.Context, id string) error {
+	deleted, err := s.repo.Delete(ctx, id)
+	if err != nil {
+		return err
+	}
+	s.publish(events.TypeSubscriptionDeleted, deleted)
+	return nil
+}
+
+// ListSubscriptions returns a page of subscriptions matching filter,
+// together with the total match count and a cursor for the next page.
+func (s *SubscriptionService) ListSubscriptions(ctx context.Context, filter domain.SubscriptionFilter) (domain.SubscriptionPage, error) {
+	items, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return domain.SubscriptionPage{}, err
+	}
+
+	total, err := s.repo.Count(ctx, filter)
+	if err != nil {
+		return domain.SubscriptionPage{}, err
+	}
+
+	page := domain.SubscriptionPage{Items: items, Total: total}
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if filter.Offset+len(items) < total {
+		page.NextCursor = strconv.Itoa(filter.Offset + limit)
+	}
+	return page, nil
+}
+
+// CalculateTotal returns the prorated cost of subscriptions active in
+// [start, end]. The breakdown field is left empty unless withBreakdown is
+// true, since most callers only want the grand total.
+func (s *SubscriptionService) CalculateTotal(ctx context.Context, start, end domain.MonthYear, filter domain.SubscriptionFilter, withBreakdown bool) (domain.CostSummary, error) {
+	summary, err := s.repo.Total(ctx, start, end, filter)
+	if err != nil {
+		return domain.CostSummary{}, err
+	}
+	if !withBreakdown {
+		summary.Breakdown = nil
+	}
+	return summary, nil
+}
+
+// publish builds and publishes a CloudEvent for a subscription lifecycle
+// change. Publishing is best-effort: a failure to build or publish the
+// event is logged but never fails the request, since the outbox already
+// guarantees reliable webhook delivery for these events.
+func (s *SubscriptionService) publish(eventType string, sub domain.Subscription) {
+	event, err := events.NewCloudEvent(eventType, subscriptionEventData{
+		Subscription: sub,
+		UserID:       sub.UserID,
+		ServiceName:  sub.ServiceName,
+	})
+	if err != nil {
+		s.logger.Error("failed to build cloud event", "type", eventType, "error", err)
+		return
+	}
+	s.bus.Publish(event)
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"efectz/internal/domain"
+	"efectz/internal/events"
+	"efectz/internal/repository/inmemory"
+)
+
+func newTestService() *SubscriptionService {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewSubscriptionService(inmemory.NewSubscriptionRepository(), events.NewBus(), logger)
+}
+
+func TestSubscriptionServiceCreateGetDelete(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService()
+
+	startDate, err := domain.ParseMonthYear("01-2024")
+	if err != nil {
+		t.Fatalf("ParseMonthYear: %v", err)
+	}
+
+	created, err := svc.CreateSubscription(ctx, domain.Subscription{
+		ServiceName: "netflix",
+		Price:       1500,
+		UserID:      "user-1",
+		StartDate:   startDate,
+	})
+	if err != nil {
+		t.Fatalf("CreateSubscription: %v", err)
+	}
+
+	got, err := svc.GetSubscription(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("GetSubscription: %v", err)
+	}
+	if got.ServiceName != "netflix" {
+		t.Errorf("GetSubscription returned ServiceName %q, want %q", got.ServiceName, "netflix")
+	}
+
+	if err := svc.DeleteSubscription(ctx, created.ID); err != nil {
+		t.Fatalf("DeleteSubscription: %v", err)
+	}
+	if _, err := svc.GetSubscription(ctx, created.ID); err == nil {
+		t.Error("GetSubscription after delete: expected an error, got nil")
+	}
+}
+
+func TestSubscriptionServiceListSubscriptionsPages(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService()
+
+	startDate, err := domain.ParseMonthYear("01-2024")
+	if err != nil {
+		t.Fatalf("ParseMonthYear: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		if _, err := svc.CreateSubscription(ctx, domain.Subscription{
+			ServiceName: "svc",
+			Price:       100,
+			UserID:      "user-1",
+			StartDate:   startDate,
+		}); err != nil {
+			t.Fatalf("CreateSubscription: %v", err)
+		}
+	}
+
+	page, err := svc.ListSubscriptions(ctx, domain.SubscriptionFilter{UserID: "user-1", Limit: 2})
+	if err != nil {
+		t.Fatalf("ListSubscriptions: %v", err)
+	}
+	if page.Total != 3 {
+		t.Errorf("ListSubscriptions Total = %d, want 3", page.Total)
+	}
+	if len(page.Items) != 2 {
+		t.Errorf("ListSubscriptions returned %d items, want 2", len(page.Items))
+	}
+	if page.NextCursor == "" {
+		t.Error("ListSubscriptions NextCursor is empty, want a cursor for the remaining item")
+	}
+}
+
+func TestSubscriptionServiceCalculateTotalProratesAcrossMonths(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService()
+
+	startDate, err := domain.ParseMonthYear("01-2024")
+	if err != nil {
+		t.Fatalf("ParseMonthYear: %v", err)
+	}
+	endDate, err := domain.ParseMonthYear("03-2024")
+	if err != nil {
+		t.Fatalf("ParseMonthYear: %v", err)
+	}
+	if _, err := svc.CreateSubscription(ctx, domain.Subscription{
+		ServiceName: "netflix",
+		Price:       100,
+		UserID:      "user-1",
+		StartDate:   startDate,
+		EndDate:     &endDate,
+	}); err != nil {
+		t.Fatalf("CreateSubscription: %v", err)
+	}
+
+	queryStart, err := domain.ParseMonthYear("02-2024")
+	if err != nil {
+		t.Fatalf("ParseMonthYear: %v", err)
+	}
+	queryEnd, err := domain.ParseMonthYear("04-2024")
+	if err != nil {
+		t.Fatalf("ParseMonthYear: %v", err)
+	}
+
+	summary, err := svc.CalculateTotal(ctx, queryStart, queryEnd, domain.SubscriptionFilter{}, true)
+	if err != nil {
+		t.Fatalf("CalculateTotal: %v", err)
+	}
+	// The subscription is active Jan-Mar; the query window is Feb-Apr, so
+	// only Feb and Mar overlap.
+	if summary.Total != 200 {
+		t.Errorf("CalculateTotal = %d, want 200 (2 overlapping months at 100)", summary.Total)
+	}
+	if len(summary.Breakdown) != 2 {
+		t.Errorf("CalculateTotal breakdown has %d months, want 2", len(summary.Breakdown))
+	}
+}
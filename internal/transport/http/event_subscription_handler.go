@@ -0,0 +1,88 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"efectz/internal/events"
+	"efectz/internal/webhook"
+)
+
+// EventSubscriptionHandler exposes the CloudEvents push interface: external
+// clients register a callback URL and resource filter, and receive a
+// CloudEvents 1.0 envelope for every matching subscription lifecycle
+// change.
+type EventSubscriptionHandler struct {
+	repo   *events.Repository
+	logger *slog.Logger
+}
+
+// NewEventSubscriptionHandler returns an EventSubscriptionHandler backed by
+// repo.
+func NewEventSubscriptionHandler(repo *events.Repository, logger *slog.Logger) *EventSubscriptionHandler {
+	return &EventSubscriptionHandler{repo: repo, logger: logger}
+}
+
+// CreateEventSubscription godoc
+// @Summary Subscribe to the CloudEvents stream
+// @Description Register a callback URL and resource filter to receive subscription lifecycle CloudEvents
+// @Tags events
+// @Accept json
+// @Produce json
+// @Param subscription body events.EventSubscription true "Event subscription data"
+// @Success 201 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /events/subscriptions [post]
+func (h *EventSubscriptionHandler) CreateEventSubscription(c echo.Context) error {
+	var sub events.EventSubscription
+	if err := c.Bind(&sub); err != nil {
+		h.logger.Error("failed to bind request", "error", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	if err := webhook.ValidateCallbackURL(sub.CallbackURL); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	created, err := h.repo.Create(c.Request().Context(), sub)
+	if err != nil {
+		h.logger.Error("failed to create event subscription", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to create event subscription"})
+	}
+
+	h.logger.Info("event subscription created", "id", created.ID)
+	return c.JSON(http.StatusCreated, map[string]string{"subscriptionId": created.ID})
+}
+
+// ListEventSubscriptions godoc
+// @Summary List active CloudEvents subscriptions
+// @Tags events
+// @Produce json
+// @Success 200 {array} events.EventSubscription
+// @Router /events/subscriptions [get]
+func (h *EventSubscriptionHandler) ListEventSubscriptions(c echo.Context) error {
+	subs, err := h.repo.List(c.Request().Context())
+	if err != nil {
+		h.logger.Error("failed to list event subscriptions", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to list event subscriptions"})
+	}
+	return c.JSON(http.StatusOK, subs)
+}
+
+// DeleteEventSubscription godoc
+// @Summary Cancel a CloudEvents subscription
+// @Tags events
+// @Param id path string true "Event subscription ID"
+// @Success 204
+// @Failure 404 {object} map[string]string
+// @Router /events/subscriptions/{id} [delete]
+func (h *EventSubscriptionHandler) DeleteEventSubscription(c echo.Context) error {
+	id := c.Param("id")
+	if err := h.repo.Delete(c.Request().Context(), id); err != nil {
+		h.logger.Error("failed to delete event subscription", "id", id, "error", err)
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "event subscription not found"})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
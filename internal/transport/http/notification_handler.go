@@ -0,0 +1,137 @@
+package http
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+
+	"efectz/internal/notifier"
+	"efectz/internal/webhook"
+)
+
+// NotificationHandler exposes CRUD endpoints for registering webhook
+// callbacks ("status subscriptions") that receive subscription lifecycle
+// events via the notifier dispatcher.
+type NotificationHandler struct {
+	repo   *notifier.Repository
+	logger *slog.Logger
+}
+
+// NewNotificationHandler returns a NotificationHandler backed by repo.
+func NewNotificationHandler(repo *notifier.Repository, logger *slog.Logger) *NotificationHandler {
+	return &NotificationHandler{repo: repo, logger: logger}
+}
+
+// CreateStatusSubscription godoc
+// @Summary Register a webhook callback
+// @Description Register a callback URL to receive subscription lifecycle events
+// @Tags status-subscriptions
+// @Accept json
+// @Produce json
+// @Param subscription body notifier.StatusSubscription true "Status subscription data"
+// @Success 201 {object} notifier.StatusSubscription
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /status-subscriptions [post]
+func (h *NotificationHandler) CreateStatusSubscription(c echo.Context) error {
+	var sub notifier.StatusSubscription
+	if err := c.Bind(&sub); err != nil {
+		h.logger.Error("failed to bind request", "error", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	if err := webhook.ValidateCallbackURL(sub.CallbackURL); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	created, err := h.repo.Create(c.Request().Context(), sub)
+	if err != nil {
+		h.logger.Error("failed to create status subscription", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to create status subscription"})
+	}
+
+	h.logger.Info("status subscription created", "id", created.ID)
+	return c.JSON(http.StatusCreated, created)
+}
+
+// GetStatusSubscription godoc
+// @Summary Get a webhook callback registration
+// @Tags status-subscriptions
+// @Produce json
+// @Param id path string true "Status subscription ID"
+// @Success 200 {object} notifier.StatusSubscription
+// @Failure 404 {object} map[string]string
+// @Router /status-subscriptions/{id} [get]
+func (h *NotificationHandler) GetStatusSubscription(c echo.Context) error {
+	id := c.Param("id")
+	sub, err := h.repo.Get(c.Request().Context(), id)
+	if err != nil {
+		h.logger.Error("failed to get status subscription", "id", id, "error", err)
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "status subscription not found"})
+	}
+	return c.JSON(http.StatusOK, sub)
+}
+
+// UpdateStatusSubscription godoc
+// @Summary Update a webhook callback registration
+// @Tags status-subscriptions
+// @Accept json
+// @Produce json
+// @Param id path string true "Status subscription ID"
+// @Param subscription body notifier.StatusSubscription true "Status subscription data"
+// @Success 200 {object} notifier.StatusSubscription
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /status-subscriptions/{id} [put]
+func (h *NotificationHandler) UpdateStatusSubscription(c echo.Context) error {
+	id := c.Param("id")
+	var sub notifier.StatusSubscription
+	if err := c.Bind(&sub); err != nil {
+		h.logger.Error("failed to bind request", "error", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+	if err := webhook.ValidateCallbackURL(sub.CallbackURL); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	updated, err := h.repo.Update(c.Request().Context(), id, sub)
+	if err != nil {
+		h.logger.Error("failed to update status subscription", "id", id, "error", err)
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "status subscription not found"})
+	}
+
+	return c.JSON(http.StatusOK, updated)
+}
+
+// DeleteStatusSubscription godoc
+// @Summary Remove a webhook callback registration
+// @Tags status-subscriptions
+// @Param id path string true "Status subscription ID"
+// @Success 204
+// @Failure 404 {object} map[string]string
+// @Router /status-subscriptions/{id} [delete]
+func (h *NotificationHandler) DeleteStatusSubscription(c echo.Context) error {
+	id := c.Param("id")
+	if err := h.repo.Delete(c.Request().Context(), id); err != nil {
+		h.logger.Error("failed to delete status subscription", "id", id, "error", err)
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "status subscription not found"})
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListStatusSubscriptions godoc
+// @Summary List webhook callback registrations
+// @Tags status-subscriptions
+// @Produce json
+// @Param user_id query string false "User ID filter"
+// @Success 200 {array} notifier.StatusSubscription
+// @Router /status-subscriptions [get]
+func (h *NotificationHandler) ListStatusSubscriptions(c echo.Context) error {
+	userID := c.QueryParam("user_id")
+	subs, err := h.repo.List(c.Request().Context(), userID)
+	if err != nil {
+		h.logger.Error("failed to list status subscriptions", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to list status subscriptions"})
+	}
+	return c.JSON(http.StatusOK, subs)
+}
@@ -0,0 +1,44 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	echoSwagger "github.com/swaggo/echo-swagger"
+)
+
+// @title Subscription Service API
+// @version 1.0
+// @description REST API for managing user subscriptions
+// @host localhost:8080
+// @BasePath /api/v1
+
+// RegisterRoutes mounts the v1 API and Swagger UI on e.
+func RegisterRoutes(e *echo.Echo, subscriptions *SubscriptionHandler, notifications *NotificationHandler, eventSubscriptions *EventSubscriptionHandler) {
+	v1 := e.Group("/api/v1")
+
+	v1.POST("/subscriptions", subscriptions.CreateSubscription)
+	v1.GET("/subscriptions/:id", subscriptions.GetSubscription)
+	v1.PUT("/subscriptions/:id", subscriptions.UpdateSubscription)
+	v1.DELETE("/subscriptions/:id", subscriptions.DeleteSubscription)
+	v1.GET("/subscriptions", subscriptions.ListSubscriptions)
+	v1.GET("/subscriptions/total", subscriptions.CalculateTotal)
+
+	v1.POST("/status-subscriptions", notifications.CreateStatusSubscription)
+	v1.GET("/status-subscriptions/:id", notifications.GetStatusSubscription)
+	v1.PUT("/status-subscriptions/:id", notifications.UpdateStatusSubscription)
+	v1.DELETE("/status-subscriptions/:id", notifications.DeleteStatusSubscription)
+	v1.GET("/status-subscriptions", notifications.ListStatusSubscriptions)
+
+	v1.POST("/events/subscriptions", eventSubscriptions.CreateEventSubscription)
+	v1.GET("/events/subscriptions", eventSubscriptions.ListEventSubscriptions)
+	v1.DELETE("/events/subscriptions/:id", eventSubscriptions.DeleteEventSubscription)
+
+	e.GET("/swagger/*", func(c echo.Context) error {
+		if err := echoSwagger.WrapHandler(c); err != nil {
+			c.Logger().Error("Swagger error: ", err)
+			return echo.NewHTTPError(http.StatusInternalServerError, "Swagger failed")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,217 @@
+// Package http wires the Echo routes and HTTP handlers on top of the
+// service layer.
+package http
+
+import (
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+
+	"efectz/internal/domain"
+	"efectz/internal/service"
+)
+
+// SubscriptionHandler exposes the subscription CRUD endpoints.
+type SubscriptionHandler struct {
+	service *service.SubscriptionService
+	logger  *slog.Logger
+}
+
+// NewSubscriptionHandler returns a SubscriptionHandler backed by svc.
+func NewSubscriptionHandler(svc *service.SubscriptionService, logger *slog.Logger) *SubscriptionHandler {
+	return &SubscriptionHandler{service: svc, logger: logger}
+}
+
+// CreateSubscription godoc
+// @Summary Create a new subscription
+// @Description Create a new subscription record
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Param subscription body domain.Subscription true "Subscription data"
+// @Success 201 {object} domain.Subscription
+// @Failure 400 {object} map[string]string
+// @Failure 500 {object} map[string]string
+// @Router /subscriptions [post]
+func (h *SubscriptionHandler) CreateSubscription(c echo.Context) error {
+	var sub domain.Subscription
+	if err := c.Bind(&sub); err != nil {
+		h.logger.Error("failed to bind request", "error", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	created, err := h.service.CreateSubscription(c.Request().Context(), sub)
+	if err != nil {
+		h.logger.Error("failed to create subscription", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to create subscription"})
+	}
+
+	h.logger.Info("subscription created", "id", created.ID)
+	return c.JSON(http.StatusCreated, created)
+}
+
+// GetSubscription godoc
+// @Summary Get a subscription
+// @Description Get subscription by ID
+// @Tags subscriptions
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Success 200 {object} domain.Subscription
+// @Failure 404 {object} map[string]string
+// @Router /subscriptions/{id} [get]
+func (h *SubscriptionHandler) GetSubscription(c echo.Context) error {
+	id := c.Param("id")
+	sub, err := h.service.GetSubscription(c.Request().Context(), id)
+	if err != nil {
+		h.logger.Error("failed to get subscription", "id", id, "error", err)
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "subscription not found"})
+	}
+
+	h.logger.Info("subscription retrieved", "id", id)
+	return c.JSON(http.StatusOK, sub)
+}
+
+// UpdateSubscription godoc
+// @Summary Update a subscription
+// @Description Update subscription by ID
+// @Tags subscriptions
+// @Accept json
+// @Produce json
+// @Param id path string true "Subscription ID"
+// @Param subscription body domain.Subscription true "Subscription data"
+// @Success 200 {object} domain.Subscription
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /subscriptions/{id} [put]
+func (h *SubscriptionHandler) UpdateSubscription(c echo.Context) error {
+	id := c.Param("id")
+	var sub domain.Subscription
+	if err := c.Bind(&sub); err != nil {
+		h.logger.Error("failed to bind request", "error", err)
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	updated, err := h.service.UpdateSubscription(c.Request().Context(), id, sub)
+	if err != nil {
+		h.logger.Error("failed to update subscription", "id", id, "error", err)
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "subscription not found"})
+	}
+
+	h.logger.Info("subscription updated", "id", id)
+	return c.JSON(http.StatusOK, updated)
+}
+
+// DeleteSubscription godoc
+// @Summary Delete a subscription
+// @Description Delete subscription by ID
+// @Tags subscriptions
+// @Param id path string true "Subscription ID"
+// @Success 204
+// @Failure 404 {object} map[string]string
+// @Router /subscriptions/{id} [delete]
+func (h *SubscriptionHandler) DeleteSubscription(c echo.Context) error {
+	id := c.Param("id")
+	if err := h.service.DeleteSubscription(c.Request().Context(), id); err != nil {
+		h.logger.Error("failed to delete subscription", "id", id, "error", err)
+		return c.JSON(http.StatusNotFound, map[string]string{"error": "subscription not found"})
+	}
+
+	h.logger.Info("subscription deleted", "id", id)
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListSubscriptions godoc
+// @Summary List subscriptions
+// @Description List all subscriptions with optional filters
+// @Tags subscriptions
+// @Produce json
+// @Param user_id query string false "User ID filter"
+// @Param service_name query string false "Service name filter"
+// @Param price_min query int false "Minimum price filter"
+// @Param price_max query int false "Maximum price filter"
+// @Param active_on query string false "Only subscriptions active on this date (MM-YYYY)"
+// @Param expired query bool false "Only expired (true) or still active (false) subscriptions"
+// @Param limit query int false "Page size (default 50)"
+// @Param offset query int false "Page offset"
+// @Param sort query string false "Sort as column:asc or column:desc"
+// @Success 200 {object} domain.SubscriptionPage
+// @Router /subscriptions [get]
+func (h *SubscriptionHandler) ListSubscriptions(c echo.Context) error {
+	filter := domain.SubscriptionFilter{
+		UserID:      c.QueryParam("user_id"),
+		ServiceName: c.QueryParam("service_name"),
+		Sort:        c.QueryParam("sort"),
+	}
+	if v, err := strconv.Atoi(c.QueryParam("price_min")); err == nil {
+		filter.PriceMin = &v
+	}
+	if v, err := strconv.Atoi(c.QueryParam("price_max")); err == nil {
+		filter.PriceMax = &v
+	}
+	if v := c.QueryParam("active_on"); v != "" {
+		activeOn, err := domain.ParseMonthYear(v)
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid active_on: " + err.Error()})
+		}
+		filter.ActiveOn = &activeOn
+	}
+	if v, err := strconv.ParseBool(c.QueryParam("expired")); err == nil {
+		filter.Expired = &v
+	}
+	if v, err := strconv.Atoi(c.QueryParam("limit")); err == nil {
+		filter.Limit = v
+	}
+	if v, err := strconv.Atoi(c.QueryParam("offset")); err == nil {
+		filter.Offset = v
+	}
+
+	page, err := h.service.ListSubscriptions(c.Request().Context(), filter)
+	if err != nil {
+		h.logger.Error("failed to list subscriptions", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to list subscriptions"})
+	}
+
+	h.logger.Info("subscriptions listed", "count", len(page.Items), "total", page.Total)
+	return c.JSON(http.StatusOK, page)
+}
+
+// CalculateTotal godoc
+// @Summary Calculate total subscription cost
+// @Description Calculate the prorated total cost for subscriptions in a period
+// @Tags subscriptions
+// @Produce json
+// @Param start_date query string true "Start date (MM-YYYY)"
+// @Param end_date query string true "End date (MM-YYYY)"
+// @Param user_id query string false "User ID filter"
+// @Param service_name query string false "Service name filter"
+// @Param breakdown query bool false "Include a per-month cost breakdown"
+// @Success 200 {object} domain.CostSummary
+// @Failure 400 {object} map[string]string
+// @Router /subscriptions/total [get]
+func (h *SubscriptionHandler) CalculateTotal(c echo.Context) error {
+	start, err := domain.ParseMonthYear(c.QueryParam("start_date"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid start_date: " + err.Error()})
+	}
+	end, err := domain.ParseMonthYear(c.QueryParam("end_date"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid end_date: " + err.Error()})
+	}
+	breakdown, _ := strconv.ParseBool(c.QueryParam("breakdown"))
+
+	filter := domain.SubscriptionFilter{
+		UserID:      c.QueryParam("user_id"),
+		ServiceName: c.QueryParam("service_name"),
+	}
+
+	summary, err := h.service.CalculateTotal(c.Request().Context(), start, end, filter, breakdown)
+	if err != nil {
+		h.logger.Error("failed to calculate total", "error", err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "failed to calculate total"})
+	}
+
+	h.logger.Info("total calculated", "total", summary.Total)
+	return c.JSON(http.StatusOK, summary)
+}
@@ -0,0 +1,192 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+
+	"efectz/internal/domain"
+	"efectz/internal/events"
+	"efectz/internal/repository/inmemory"
+	"efectz/internal/service"
+)
+
+func newTestSubscriptionHandler() *SubscriptionHandler {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	svc := service.NewSubscriptionService(inmemory.NewSubscriptionRepository(), events.NewBus(), logger)
+	return NewSubscriptionHandler(svc, logger)
+}
+
+func newJSONContext(e *echo.Echo, method, path string, body interface{}) (echo.Context, *httptest.ResponseRecorder) {
+	var reqBody []byte
+	if body != nil {
+		reqBody, _ = json.Marshal(body)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	return e.NewContext(req, rec), rec
+}
+
+// TestSubscriptionHandlerCreateGetUpdateDelete covers the handler's
+// happy-path CRUD wiring end to end against a real service and in-memory
+// repository.
+func TestSubscriptionHandlerCreateGetUpdateDelete(t *testing.T) {
+	e := echo.New()
+	h := newTestSubscriptionHandler()
+
+	startDate, err := domain.ParseMonthYear("01-2024")
+	if err != nil {
+		t.Fatalf("ParseMonthYear: %v", err)
+	}
+
+	c, rec := newJSONContext(e, "POST", "/api/v1/subscriptions", domain.Subscription{
+		ServiceName: "netflix",
+		Price:       1500,
+		UserID:      "user-1",
+		StartDate:   startDate,
+	})
+	if err := h.CreateSubscription(c); err != nil {
+		t.Fatalf("CreateSubscription: %v", err)
+	}
+	if rec.Code != 201 {
+		t.Fatalf("CreateSubscription status = %d, want 201", rec.Code)
+	}
+	var created domain.Subscription
+	if err := json.Unmarshal(rec.Body.Bytes(), &created); err != nil {
+		t.Fatalf("unmarshal create response: %v", err)
+	}
+	if created.ID == "" {
+		t.Fatal("CreateSubscription did not assign an ID")
+	}
+
+	c, rec = newJSONContext(e, "GET", "/api/v1/subscriptions/"+created.ID, nil)
+	c.SetParamNames("id")
+	c.SetParamValues(created.ID)
+	if err := h.GetSubscription(c); err != nil {
+		t.Fatalf("GetSubscription: %v", err)
+	}
+	if rec.Code != 200 {
+		t.Fatalf("GetSubscription status = %d, want 200", rec.Code)
+	}
+
+	created.Price = 2000
+	c, rec = newJSONContext(e, "PUT", "/api/v1/subscriptions/"+created.ID, created)
+	c.SetParamNames("id")
+	c.SetParamValues(created.ID)
+	if err := h.UpdateSubscription(c); err != nil {
+		t.Fatalf("UpdateSubscription: %v", err)
+	}
+	if rec.Code != 200 {
+		t.Fatalf("UpdateSubscription status = %d, want 200", rec.Code)
+	}
+	var updated domain.Subscription
+	if err := json.Unmarshal(rec.Body.Bytes(), &updated); err != nil {
+		t.Fatalf("unmarshal update response: %v", err)
+	}
+	if updated.Price != 2000 {
+		t.Errorf("UpdateSubscription did not persist Price, got %d, want 2000", updated.Price)
+	}
+
+	c, rec = newJSONContext(e, "DELETE", "/api/v1/subscriptions/"+created.ID, nil)
+	c.SetParamNames("id")
+	c.SetParamValues(created.ID)
+	if err := h.DeleteSubscription(c); err != nil {
+		t.Fatalf("DeleteSubscription: %v", err)
+	}
+	if rec.Code != 204 {
+		t.Fatalf("DeleteSubscription status = %d, want 204", rec.Code)
+	}
+
+	c, rec = newJSONContext(e, "GET", "/api/v1/subscriptions/"+created.ID, nil)
+	c.SetParamNames("id")
+	c.SetParamValues(created.ID)
+	if err := h.GetSubscription(c); err != nil {
+		t.Fatalf("GetSubscription after delete: %v", err)
+	}
+	if rec.Code != 404 {
+		t.Fatalf("GetSubscription after delete status = %d, want 404", rec.Code)
+	}
+}
+
+// TestSubscriptionHandlerGetSubscriptionNotFound covers the 404 path for
+// an ID that never existed.
+func TestSubscriptionHandlerGetSubscriptionNotFound(t *testing.T) {
+	e := echo.New()
+	h := newTestSubscriptionHandler()
+
+	c, rec := newJSONContext(e, "GET", "/api/v1/subscriptions/does-not-exist", nil)
+	c.SetParamNames("id")
+	c.SetParamValues("does-not-exist")
+	if err := h.GetSubscription(c); err != nil {
+		t.Fatalf("GetSubscription: %v", err)
+	}
+	if rec.Code != 404 {
+		t.Fatalf("GetSubscription status = %d, want 404", rec.Code)
+	}
+}
+
+// TestSubscriptionHandlerCreateSubscriptionInvalidBody covers the 400 path
+// for a request body that doesn't bind to domain.Subscription.
+func TestSubscriptionHandlerCreateSubscriptionInvalidBody(t *testing.T) {
+	e := echo.New()
+	h := newTestSubscriptionHandler()
+
+	req := httptest.NewRequest("POST", "/api/v1/subscriptions", bytes.NewReader([]byte("not json")))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+
+	if err := h.CreateSubscription(c); err != nil {
+		t.Fatalf("CreateSubscription: %v", err)
+	}
+	if rec.Code != 400 {
+		t.Fatalf("CreateSubscription status = %d, want 400", rec.Code)
+	}
+}
+
+// TestSubscriptionHandlerListSubscriptionsFiltersByUserID covers the
+// query-param filter wiring for ListSubscriptions.
+func TestSubscriptionHandlerListSubscriptionsFiltersByUserID(t *testing.T) {
+	e := echo.New()
+	h := newTestSubscriptionHandler()
+	startDate, err := domain.ParseMonthYear("01-2024")
+	if err != nil {
+		t.Fatalf("ParseMonthYear: %v", err)
+	}
+
+	for _, userID := range []string{"user-1", "user-2"} {
+		c, _ := newJSONContext(e, "POST", "/api/v1/subscriptions", domain.Subscription{
+			ServiceName: "netflix",
+			Price:       1000,
+			UserID:      userID,
+			StartDate:   startDate,
+		})
+		if err := h.CreateSubscription(c); err != nil {
+			t.Fatalf("CreateSubscription: %v", err)
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/api/v1/subscriptions?user_id=user-1", nil)
+	rec := httptest.NewRecorder()
+	c := e.NewContext(req, rec)
+	if err := h.ListSubscriptions(c); err != nil {
+		t.Fatalf("ListSubscriptions: %v", err)
+	}
+	if rec.Code != 200 {
+		t.Fatalf("ListSubscriptions status = %d, want 200", rec.Code)
+	}
+
+	var page domain.SubscriptionPage
+	if err := json.Unmarshal(rec.Body.Bytes(), &page); err != nil {
+		t.Fatalf("unmarshal list response: %v", err)
+	}
+	if len(page.Items) != 1 || page.Items[0].UserID != "user-1" {
+		t.Fatalf("ListSubscriptions(user_id=user-1) returned %+v, want exactly one item for user-1", page.Items)
+	}
+}
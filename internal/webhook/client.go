@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+// NewSafeClient returns an http.Client for delivering to client-supplied
+// callback URLs. ValidateCallbackURL only runs at registration time, which
+// leaves two live SSRF routes open if the delivery client is a bare
+// http.Client: a hostname can be repointed at a disallowed address between
+// registration and a later delivery attempt (DNS rebinding), and a
+// redirect response can send the request anywhere with no re-validation at
+// all. This client closes both: every dial re-validates the address it is
+// about to connect to, and redirects are never followed automatically.
+func NewSafeClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{Timeout: timeout}
+	transport := &http.Transport{
+		DialContext: safeDialContext(dialer),
+	}
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// safeDialContext returns a DialContext that resolves addr's host, rejects
+// it if any resolved IP is disallowed, and connects to the validated IP
+// directly rather than letting the standard dialer re-resolve the
+// hostname itself, so a second lookup can't return a different, unvalidated
+// address for the same connection.
+func safeDialContext(dialer *net.Dialer) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+
+		ips, err := net.DefaultResolver.LookupIP(ctx, "ip", host)
+		if err != nil {
+			return nil, fmt.Errorf("resolve %q: %w", host, err)
+		}
+		for _, ip := range ips {
+			if isDisallowedIP(ip) {
+				return nil, fmt.Errorf("refusing to dial disallowed address %s for host %q", ip, host)
+			}
+		}
+
+		return dialer.DialContext(ctx, network, net.JoinHostPort(ips[0].String(), port))
+	}
+}
@@ -0,0 +1,35 @@
+package webhook
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSafeDialContextRejectsDisallowedAddresses(t *testing.T) {
+	dial := safeDialContext(&net.Dialer{Timeout: time.Second})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	addrs := []string{"127.0.0.1:80", "169.254.169.254:80", "10.0.0.5:80", "0.0.0.0:80", "localhost:80"}
+	for _, addr := range addrs {
+		if _, err := dial(ctx, "tcp", addr); err == nil || !strings.Contains(err.Error(), "disallowed") {
+			t.Errorf("dial(%q) error = %v, want a disallowed-address error", addr, err)
+		}
+	}
+}
+
+func TestSafeDialContextDoesNotRejectPublicAddressesAtValidation(t *testing.T) {
+	dial := safeDialContext(&net.Dialer{Timeout: 200 * time.Millisecond})
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	// The actual TCP connect may still fail in a sandboxed/offline test
+	// environment; what matters is that validation itself doesn't flag a
+	// public address as disallowed.
+	if _, err := dial(ctx, "tcp", "8.8.8.8:443"); err != nil && strings.Contains(err.Error(), "disallowed") {
+		t.Errorf("dial(%q) rejected a public address: %v", "8.8.8.8:443", err)
+	}
+}
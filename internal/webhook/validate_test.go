@@ -0,0 +1,31 @@
+package webhook
+
+import "testing"
+
+func TestValidateCallbackURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		wantErr bool
+	}{
+		{name: "public https host", url: "https://8.8.8.8/webhook", wantErr: false},
+		{name: "public http host", url: "http://8.8.8.8/webhook", wantErr: false},
+		{name: "rejects non-http scheme", url: "ftp://example.com/webhook", wantErr: true},
+		{name: "rejects malformed url", url: "://nope", wantErr: true},
+		{name: "rejects loopback", url: "http://127.0.0.1/webhook", wantErr: true},
+		{name: "rejects localhost", url: "http://localhost/webhook", wantErr: true},
+		{name: "rejects private 10.x", url: "http://10.0.0.5/webhook", wantErr: true},
+		{name: "rejects private 192.168.x", url: "http://192.168.1.1/webhook", wantErr: true},
+		{name: "rejects link-local metadata address", url: "http://169.254.169.254/latest/meta-data", wantErr: true},
+		{name: "rejects unspecified address", url: "http://0.0.0.0/webhook", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCallbackURL(tt.url)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateCallbackURL(%q) error = %v, wantErr %v", tt.url, err, tt.wantErr)
+			}
+		})
+	}
+}